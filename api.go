@@ -1,7 +1,11 @@
 package consolex
 
 import (
+	"context"
+	"io"
+	"log/slog"
 	"os"
+	"os/exec"
 
 	"github.com/VexoraDevelopment/consolex/cmdline"
 	"github.com/VexoraDevelopment/consolex/logging"
@@ -10,6 +14,14 @@ import (
 
 type Chalk = style.Chalk
 type Theme = style.Theme
+type Variant = style.Variant
+type ThemeSpec = style.ThemeSpec
+type ContrastIssue = style.ContrastIssue
+
+const (
+	VariantDark  = style.VariantDark
+	VariantLight = style.VariantLight
+)
 
 func New() Chalk      { return style.New() }
 func Disabled() Chalk { return style.Disabled() }
@@ -18,6 +30,28 @@ func DefaultTheme() Theme { return style.DefaultTheme() }
 func NordTheme() Theme    { return style.NordTheme() }
 func SunsetTheme() Theme  { return style.SunsetTheme() }
 
+func HighContrastTheme() Theme      { return style.HighContrastTheme() }
+func HighContrastLightTheme() Theme { return style.HighContrastLightTheme() }
+func ColorBlindTheme() Theme        { return style.ColorBlindTheme() }
+func ColorBlindLightTheme() Theme   { return style.ColorBlindLightTheme() }
+
+func GenerateTheme(spec ThemeSpec) (Theme, error) { return style.GenerateTheme(spec) }
+func GenerateVariants(spec ThemeSpec) (dark, light Theme, err error) {
+	return style.GenerateVariants(spec)
+}
+func AdaptTheme(theme Theme, variant Variant) Theme { return style.AdaptTheme(theme, variant) }
+func CheckContrast(theme Theme, minRatio float64) []ContrastIssue {
+	return style.CheckContrast(theme, minRatio)
+}
+
+func ParseStyle(spec string) (Chalk, error)    { return style.ParseStyle(spec) }
+func ParseTheme(data []byte) (Theme, error)    { return style.ParseTheme(data) }
+func LoadTheme(path string) (Theme, error)     { return style.LoadTheme(path) }
+func SaveTheme(path string, theme Theme) error { return style.SaveTheme(path, theme) }
+func RegisterTheme(name string, theme Theme)   { style.RegisterTheme(name, theme) }
+func LookupTheme(name string) (Theme, bool)    { return style.LookupTheme(name) }
+func ThemeNames() []string                     { return style.ThemeNames() }
+
 type LoggerConfig = logging.LoggerConfig
 type DedupeConfig = logging.DedupeConfig
 type LevelRemapRule = logging.LevelRemapRule
@@ -33,9 +67,114 @@ type FieldStyleFunc = logging.FieldStyleFunc
 type StaticFieldProvider = logging.StaticFieldProvider
 type FieldTransformer = logging.FieldTransformer
 type FieldTransformFunc = logging.FieldTransformFunc
+type TemplateRenderer = logging.TemplateRenderer
+type TimeMode = logging.TimeMode
+type LevelSpec = logging.LevelSpec
+type ValueKind = logging.ValueKind
+type HighlightRule = logging.HighlightRule
+type HighlightSet = logging.HighlightSet
+type HashColorKey = logging.HashColorKey
+type HashAssignment = logging.HashAssignment
+type HashColorProvider = logging.HashColorProvider
+type MultiFieldProvider = logging.MultiFieldProvider
+type HumanizeKind = logging.HumanizeKind
+type HumanizerConfig = logging.HumanizerConfig
+type ProcessingWriter = logging.ProcessingWriter
+type Parser = logging.Parser
+type ParserFunc = logging.ParserFunc
+type Pipeline = logging.Pipeline
+type ColorizingWriter = logging.ColorizingWriter
+type CaptureConfig = logging.CaptureConfig
+type CaptureWriter = logging.CaptureWriter
+type RecordRing = logging.RecordRing
+type RingRecord = logging.RingRecord
+type RecordQuery = logging.RecordQuery
+type LogSource = logging.LogSource
+type LogLine = logging.LogLine
+type ArchiveIndex = logging.ArchiveIndex
+type SearchStats = logging.SearchStats
+type Metrics = logging.Metrics
+type MetricsSnapshot = logging.MetricsSnapshot
+
+var (
+	SlogTextParser = logging.SlogTextParser
+	LogfmtParser   = logging.LogfmtParser
+	JSONParser     = logging.JSONParser
+	GoLogParser    = logging.GoLogParser
+	RawParser      = logging.RawParser
+	AutoParser     = logging.AutoParser
+)
+
+var HandleCrash = logging.HandleCrash
 
-func DefaultProfile() Profile                 { return logging.DefaultProfile() }
-func ParseTextLogLine(line string) *LogRecord { return logging.ParseTextLogLine(line) }
+const (
+	LevelTrace  = logging.LevelTrace
+	LevelDebug  = logging.LevelDebug
+	LevelInfo   = logging.LevelInfo
+	LevelNotice = logging.LevelNotice
+	LevelWarn   = logging.LevelWarn
+	LevelError  = logging.LevelError
+	LevelFatal  = logging.LevelFatal
+)
+
+const (
+	HumanizeNone     = logging.HumanizeNone
+	HumanizeBytes    = logging.HumanizeBytes
+	HumanizeDuration = logging.HumanizeDuration
+	HumanizeCount    = logging.HumanizeCount
+)
+
+const (
+	TimeAbsolute      = logging.TimeAbsolute
+	TimeElideRepeated = logging.TimeElideRepeated
+	TimeDelta         = logging.TimeDelta
+)
+
+func DefaultProfile() Profile                   { return logging.DefaultProfile() }
+func ClassifyValue(value string) ValueKind      { return logging.ClassifyValue(value) }
+func NewValueHighlighter(theme Theme) Processor { return logging.NewValueHighlighter(theme) }
+func Highlights() *HighlightSet                 { return logging.Highlights() }
+func NewHighlightSet(rules ...HighlightRule) (*HighlightSet, error) {
+	return logging.NewHighlightSet(rules...)
+}
+func NewPipeline(theme Theme, profile Profile, provider FieldStyleProvider, transformer FieldTransformer, extras []Processor, renderer Renderer) *Pipeline {
+	return logging.NewPipeline(theme, profile, provider, transformer, extras, renderer)
+}
+func NewTemplateRenderer(format string, theme Theme, profile Profile) (*TemplateRenderer, error) {
+	return logging.NewTemplateRenderer(format, theme, profile)
+}
+func ParseTextLogLine(line string) *LogRecord    { return logging.ParseTextLogLine(line) }
+func NewHumanizer(cfg HumanizerConfig) Processor { return logging.NewHumanizer(cfg) }
+func NewAutoParser(parsers ...Parser) Parser     { return logging.NewAutoParser(parsers...) }
+func InferLevel(text string) string              { return logging.InferLevel(text) }
+func ColorizeRecord(rec *LogRecord) string       { return logging.ColorizeRecord(rec) }
+func NewColorizingWriter(dst io.Writer) *ColorizingWriter {
+	return logging.NewColorizingWriter(dst)
+}
+func NewCaptureWriter(cfg CaptureConfig, level slog.Level) *CaptureWriter {
+	return logging.NewCaptureWriter(cfg, level)
+}
+func AttachCommand(cmd *exec.Cmd, cfg CaptureConfig) (stdout, stderr *CaptureWriter) {
+	return logging.AttachCommand(cmd, cfg)
+}
+func CaptureStdio(cfg CaptureConfig) error { return logging.CaptureStdio(cfg) }
+func RestoreStdio() error                  { return logging.RestoreStdio() }
+func ReportCrash(recovered any, stack []byte) (string, error) {
+	return logging.ReportCrash(recovered, stack)
+}
+func LogMetrics() *Metrics               { return logging.LogMetrics() }
+func NewMetrics() *Metrics               { return logging.NewMetrics() }
+func PublishExpvar(name string) error    { return logging.PublishExpvar(name) }
+func SessionSummary() string             { return logging.SessionSummary() }
+func LogSessionSummary()                 { logging.LogSessionSummary() }
+func RecentRecords() *RecordRing         { return logging.RecentRecords() }
+func NewRecordRing(size int) *RecordRing { return logging.NewRecordRing(size) }
+func ReportCommandPanic(name string, recovered any, stack []byte) {
+	logging.ReportCommandPanic(name, recovered, stack)
+}
+func NewProcessingWriter(dst io.Writer, processors ...Processor) *ProcessingWriter {
+	return logging.NewProcessingWriter(dst, processors...)
+}
 
 func SetupDefaultSlog(cfg LoggerConfig) (*os.File, error) {
 	return logging.SetupDefaultSlog(cfg)
@@ -45,11 +184,71 @@ func RotateAndCompressLog(srcPath, archiveDir string) error {
 	return logging.RotateAndCompressLog(srcPath, archiveDir)
 }
 
+func LogFiles(logPath, archiveDir string) ([]string, error) {
+	return logging.LogFiles(logPath, archiveDir)
+}
+func OpenLogFile(path string) (io.ReadCloser, error) { return logging.OpenLogFile(path) }
+func ScanLogFile(path string, fn func(lineNo int, line string) bool) error {
+	return logging.ScanLogFile(path, fn)
+}
+func MergeLogs(sources []LogSource, parser Parser, fn func(LogLine) bool) error {
+	return logging.MergeLogs(sources, parser, fn)
+}
+func MergeLogFiles(paths []string, parser Parser, fn func(LogLine) bool) error {
+	return logging.MergeLogFiles(paths, parser, fn)
+}
+func FollowLogFile(ctx context.Context, path string, offset int64, fn func(line string)) error {
+	return logging.FollowLogFile(ctx, path, offset, fn)
+}
+func BuildArchiveIndex(path string) (*ArchiveIndex, error) { return logging.BuildArchiveIndex(path) }
+func IndexArchive(path string) (*ArchiveIndex, error)      { return logging.IndexArchive(path) }
+func IndexArchives(paths []string) (int, error)            { return logging.IndexArchives(paths) }
+func LoadArchiveIndex(path string) (*ArchiveIndex, error)  { return logging.LoadArchiveIndex(path) }
+func PruneLogFiles(paths []string, q RecordQuery) (keep []string, skipped int) {
+	return logging.PruneLogFiles(paths, q)
+}
+func SearchLogFiles(ctx context.Context, paths []string, q RecordQuery, parser Parser, fn func(LogLine) bool) (SearchStats, error) {
+	return logging.SearchLogFiles(ctx, paths, q, parser, fn)
+}
+
+func RegisterLevel(spec LevelSpec) error        { return logging.RegisterLevel(spec) }
+func Levels() []LevelSpec                       { return logging.Levels() }
+func LookupLevel(name string) (LevelSpec, bool) { return logging.LookupLevel(name) }
+func ParseLevel(s string) (slog.Level, bool)    { return logging.ParseLevel(s) }
+func LevelName(level slog.Level) string         { return logging.LevelName(level) }
+func SetLevel(level slog.Level)                 { logging.SetLevel(level) }
+func CurrentLevel() slog.Level                  { return logging.CurrentLevel() }
+
+func SetTheme(theme Theme)    { logging.SetTheme(theme) }
+func CurrentTheme() Theme     { return logging.CurrentTheme() }
+func CurrentProfile() Profile { return logging.CurrentProfile() }
+
 func ColorizeLogLine(line string) string { return logging.ColorizeLogLine(line) }
 func StripANSI(s string) string          { return style.StripANSI(s) }
 
+func Width(s string) int                               { return style.Width(s) }
+func Truncate(s string, w int, ellipsis string) string { return style.Truncate(s, w, ellipsis) }
+func TruncateMiddle(s string, w int, ellipsis string) string {
+	return style.TruncateMiddle(s, w, ellipsis)
+}
+func PadRight(s string, w int) string     { return style.PadRight(s, w) }
+func PadLeft(s string, w int) string      { return style.PadLeft(s, w) }
+func Center(s string, w int) string       { return style.Center(s, w) }
+func Wrap(s string, w, indent int) string { return style.Wrap(s, w, indent) }
+
 type Command = cmdline.Command
 type Options = cmdline.Options
 type Loop = cmdline.Loop
 
 func NewLoop(opts Options) *Loop { return cmdline.NewLoop(opts) }
+
+func ThemeCommand(out io.Writer) Command { return logging.ThemeCommand(out) }
+func LevelCommand(out io.Writer) Command { return logging.LevelCommand(out) }
+func LogsCommand(out io.Writer) Command  { return logging.LogsCommand(out) }
+func StatsCommand(out io.Writer) Command { return logging.StatsCommand(out) }
+func LegendCommand(out io.Writer, provider *HashColorProvider) Command {
+	return logging.LegendCommand(out, provider)
+}
+func HighlightCommand(out io.Writer, set *HighlightSet) Command {
+	return logging.HighlightCommand(out, set)
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VexoraDevelopment/consolex"
+	"github.com/VexoraDevelopment/consolex/term"
+)
+
+type fieldFilters map[string]string
+
+func (f fieldFilters) String() string {
+	parts := make([]string, 0, len(f))
+	for k, v := range f {
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f fieldFilters) Set(value string) error {
+	key, want, _ := strings.Cut(value, "=")
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("bad field filter %q", value)
+	}
+	f[strings.TrimSpace(key)] = want
+	return nil
+}
+
+type termList []string
+
+func (t *termList) String() string { return strings.Join(*t, ",") }
+
+func (t *termList) Set(value string) error {
+	*t = append(*t, value)
+	return nil
+}
+
+type viewer struct {
+	pipeline *consolex.Pipeline
+	parser   consolex.Parser
+	query    consolex.RecordQuery
+	tail     int
+	index    bool
+	stats    bool
+
+	mu  sync.Mutex
+	out *bufio.Writer
+}
+
+func main() {
+	var (
+		themeName = flag.String("theme", "default", "theme name or theme file")
+		format    = flag.String("format", "", "template format, e.g. \"{time} {level} {msg} {fields}\"")
+		parser    = flag.String("parser", "auto", "line format: auto, slog, logfmt, json, golog or raw")
+		follow    = flag.Bool("f", false, "follow plain log files, reopening them after rotation")
+		tail      = flag.Int("n", 0, "show only the last n matching lines before following (0 = all)")
+		level     = flag.String("level", "", "minimum level, e.g. warn")
+		since     = flag.String("since", "", "only records at or after this time (duration like 10m, or timestamp)")
+		until     = flag.String("until", "", "only records at or before this time (duration like 10m, or timestamp)")
+		grep      = flag.String("grep", "", "case-insensitive regexp matched against the raw line")
+		aligned   = flag.Bool("aligned", false, "render records in aligned columns")
+		timeMode  = flag.String("time", "absolute", "timestamp mode: absolute, elide or delta")
+		values    = flag.Bool("values", false, "highlight values by kind")
+		humanize  = flag.Bool("humanize", false, "humanize byte sizes and durations")
+		index     = flag.Bool("index", false, "build missing .idx sidecars for archives before searching")
+		stats     = flag.Bool("stats", false, "print how many files were searched or skipped to stderr")
+		fields    = fieldFilters{}
+		terms     termList
+	)
+	flag.Var(fields, "field", "key=value filter, repeatable; a bare key only requires the field")
+	flag.Var(&terms, "term", "word that must appear in the message or a field value, repeatable; uses archive indexes")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "usage: consolex [flags] [file ...]\n\n")
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Colorizes slog text logs and .gz archives. Multiple files are merged in timestamp order.\n")
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Without files, reads logs/server_*.log.gz and server.log. Use - for stdin.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	theme, err := loadTheme(*themeName)
+	if err != nil {
+		fatal(err)
+	}
+	profile := consolex.DefaultProfile()
+	profile.Aligned = *aligned
+	switch strings.ToLower(*timeMode) {
+	case "absolute", "":
+		profile.TimeMode = consolex.TimeAbsolute
+	case "elide":
+		profile.TimeMode = consolex.TimeElideRepeated
+	case "delta":
+		profile.TimeMode = consolex.TimeDelta
+	default:
+		fatal(fmt.Errorf("unknown time mode %q", *timeMode))
+	}
+	var renderer consolex.Renderer
+	if strings.TrimSpace(*format) != "" {
+		tr, err := consolex.NewTemplateRenderer(*format, theme, profile)
+		if err != nil {
+			fatal(err)
+		}
+		renderer = tr
+	}
+	var extras []consolex.Processor
+	if *humanize {
+		extras = append(extras, consolex.NewHumanizer(consolex.HumanizerConfig{Detect: true}))
+	}
+	if *values {
+		extras = append(extras, consolex.NewValueHighlighter(theme))
+	}
+	p, err := lookupParser(*parser)
+	if err != nil {
+		fatal(err)
+	}
+	q, err := buildQuery(*level, *since, *until, *grep, fields)
+	if err != nil {
+		fatal(err)
+	}
+	q.Terms = terms
+
+	term.EnableConsoleANSI()
+	v := &viewer{
+		pipeline: consolex.NewPipeline(theme, profile, nil, nil, extras, renderer).WithParser(p),
+		parser:   p,
+		query:    q,
+		tail:     *tail,
+		index:    *index,
+		stats:    *stats,
+		out:      bufio.NewWriter(os.Stdout),
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err = v.run(ctx, flag.Args(), *follow)
+	_ = v.out.Flush()
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "consolex: %v\n", err)
+	os.Exit(1)
+}
+
+func loadTheme(name string) (consolex.Theme, error) {
+	if t, ok := consolex.LookupTheme(name); ok {
+		return t, nil
+	}
+	if _, err := os.Stat(name); err == nil {
+		return consolex.LoadTheme(name)
+	}
+	return consolex.Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(consolex.ThemeNames(), ", "))
+}
+
+func lookupParser(name string) (consolex.Parser, error) {
+	switch strings.ToLower(name) {
+	case "auto", "":
+		return consolex.AutoParser, nil
+	case "slog", "text":
+		return consolex.SlogTextParser, nil
+	case "logfmt":
+		return consolex.LogfmtParser, nil
+	case "json":
+		return consolex.JSONParser, nil
+	case "golog", "log":
+		return consolex.GoLogParser, nil
+	case "raw":
+		return consolex.RawParser, nil
+	}
+	return nil, fmt.Errorf("unknown parser %q", name)
+}
+
+func buildQuery(level, since, until, grep string, fields fieldFilters) (consolex.RecordQuery, error) {
+	q := consolex.RecordQuery{}
+	if level != "" {
+		l, ok := consolex.ParseLevel(level)
+		if !ok {
+			return q, fmt.Errorf("unknown level %q", level)
+		}
+		q.MinLevel = l
+	}
+	var err error
+	if q.Since, err = parseTime(since); err != nil {
+		return q, err
+	}
+	if q.Until, err = parseTime(until); err != nil {
+		return q, err
+	}
+	if grep != "" {
+		if q.Pattern, err = regexp.Compile("(?i)" + grep); err != nil {
+			return q, fmt.Errorf("bad -grep: %w", err)
+		}
+	}
+	if len(fields) > 0 {
+		q.Fields = fields
+	}
+	return q, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad time %q", value)
+}
+
+func (v *viewer) run(ctx context.Context, paths []string, follow bool) error {
+	if len(paths) == 0 {
+		files, err := consolex.LogFiles("", "")
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 && !follow {
+			return errors.New("no log files found; pass file paths as arguments")
+		}
+		paths = files
+		if follow && !containsPath(paths, "server.log") {
+			paths = append(paths, "server.log")
+		}
+	}
+
+	if v.index {
+		if _, err := consolex.IndexArchives(paths); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "consolex: index: %v\n", err)
+		}
+	}
+	paths, skipped := consolex.PruneLogFiles(paths, v.query)
+
+	sources := make([]consolex.LogSource, 0, len(paths))
+	offsets := map[string]int64{}
+	for _, path := range paths {
+		if path == "-" {
+			sources = append(sources, consolex.LogSource{Name: "stdin", R: os.Stdin})
+			continue
+		}
+		rc, err := consolex.OpenLogFile(path)
+		if err != nil {
+			if follow && os.IsNotExist(err) && isLive(path) {
+				offsets[path] = 0
+				continue
+			}
+			return err
+		}
+		defer func(rc io.ReadCloser) {
+			_ = rc.Close()
+		}(rc)
+		var r io.Reader = rc
+		if follow && isLive(path) {
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			offsets[path] = info.Size()
+			r = io.LimitReader(rc, info.Size())
+		}
+		sources = append(sources, consolex.LogSource{Name: path, R: r})
+	}
+
+	var backlog []*consolex.LogRecord
+	lines, matches := 0, 0
+	err := consolex.MergeLogs(sources, v.parser, func(line consolex.LogLine) bool {
+		lines++
+		if !v.query.MatchRecord(line.Record) {
+			return ctx.Err() == nil
+		}
+		matches++
+		if v.tail <= 0 {
+			v.print(line.Record)
+			return ctx.Err() == nil
+		}
+		backlog = append(backlog, line.Record)
+		if len(backlog) > v.tail {
+			backlog = backlog[1:]
+		}
+		return ctx.Err() == nil
+	})
+	for _, rec := range backlog {
+		v.print(rec)
+	}
+	if v.stats {
+		_ = v.out.Flush()
+		_, _ = fmt.Fprintf(os.Stderr, "consolex: searched %d files (%d skipped by index), %d lines, %d matches\n", len(sources), skipped, lines, matches)
+	}
+	if err != nil || !follow {
+		return err
+	}
+	if len(offsets) == 0 {
+		return errors.New("-f needs at least one plain (not .gz) log file")
+	}
+	_ = v.out.Flush()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(offsets))
+	for path, offset := range offsets {
+		wg.Add(1)
+		go func(path string, offset int64) {
+			defer wg.Done()
+			errs <- consolex.FollowLogFile(ctx, path, offset, func(line string) {
+				rec := v.pipeline.Parse(line)
+				if !v.query.MatchRecord(rec) {
+					return
+				}
+				v.mu.Lock()
+				defer v.mu.Unlock()
+				v.print(rec)
+				_ = v.out.Flush()
+			})
+		}(path, offset)
+	}
+	wg.Wait()
+	close(errs)
+	var all []error
+	for err := range errs {
+		all = append(all, err)
+	}
+	return errors.Join(all...)
+}
+
+func (v *viewer) print(rec *consolex.LogRecord) {
+	_, _ = v.out.WriteString(v.pipeline.Render(rec))
+	_ = v.out.WriteByte('\n')
+}
+
+func isLive(path string) bool {
+	return path != "-" && !strings.HasSuffix(path, ".gz")
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
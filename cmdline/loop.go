@@ -3,13 +3,16 @@ package cmdline
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"runtime/debug"
 	"sort"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/VexoraDevelopment/consolex/term"
 	"github.com/chzyer/readline"
 )
 
@@ -31,6 +34,7 @@ type Options struct {
 	Resolve         func(name, args string) bool
 	CommandNames    func(prefix string) []string
 	ArgSuggestions  func(name string, argPos int, prefix string) []string
+	OnPanic         func(name string, recovered any, stack []byte)
 	Out             *os.File
 	Log             *slog.Logger
 }
@@ -55,9 +59,6 @@ func NewLoop(opts Options) *Loop {
 	if strings.TrimSpace(opts.EOFPrompt) == "" {
 		opts.EOFPrompt = "exit"
 	}
-	if opts.Out == nil {
-		opts.Out = os.Stdout
-	}
 	if opts.Log == nil {
 		opts.Log = slog.Default()
 	}
@@ -72,9 +73,9 @@ func NewLoop(opts Options) *Loop {
 		Description: "Show available console commands",
 		Execute: func(string) {
 			names := l.availableNames("")
-			_, _ = fmt.Fprintln(opts.Out, "Available commands:")
+			_, _ = fmt.Fprintln(l.out(), "Available commands:")
 			for _, n := range names {
-				_, _ = fmt.Fprintln(opts.Out, " - "+n)
+				_, _ = fmt.Fprintln(l.out(), " - "+n)
 			}
 		},
 	})
@@ -83,28 +84,28 @@ func NewLoop(opts Options) *Loop {
 		Aliases:     []string{"cls"},
 		Description: "Clear terminal screen",
 		Execute: func(string) {
-			_, _ = fmt.Fprint(opts.Out, "\x1b[H\x1b[2J")
+			_, _ = fmt.Fprint(l.out(), "\x1b[H\x1b[2J")
 		},
 	})
 	l.Register(Command{
 		Name:        "uptime",
 		Description: "Show console loop uptime",
 		Execute: func(string) {
-			_, _ = fmt.Fprintf(opts.Out, "uptime: %s\n", time.Since(l.started).Truncate(time.Second))
+			_, _ = fmt.Fprintf(l.out(), "uptime: %s\n", time.Since(l.started).Truncate(time.Second))
 		},
 	})
 	l.Register(Command{
 		Name:        "pid",
 		Description: "Show current process id",
 		Execute: func(string) {
-			_, _ = fmt.Fprintf(opts.Out, "pid: %d\n", os.Getpid())
+			_, _ = fmt.Fprintf(l.out(), "pid: %d\n", os.Getpid())
 		},
 	})
 	l.Register(Command{
 		Name:        "echo",
 		Description: "Print text back to console",
 		Execute: func(args string) {
-			_, _ = fmt.Fprintln(opts.Out, args)
+			_, _ = fmt.Fprintln(l.out(), args)
 		},
 	})
 	return l
@@ -141,6 +142,13 @@ func (l *Loop) Register(c Command) {
 	}
 }
 
+func (l *Loop) out() io.Writer {
+	if l.opts.Out != nil {
+		return l.opts.Out
+	}
+	return term.ConsoleOut()
+}
+
 func (l *Loop) Start() <-chan struct{} {
 	go func() {
 		defer close(l.done)
@@ -150,6 +158,8 @@ func (l *Loop) Start() <-chan struct{} {
 			InterruptPrompt: l.opts.InterruptPrompt,
 			EOFPrompt:       l.opts.EOFPrompt,
 			AutoComplete:    &completer{loop: l},
+			Stdout:          l.out(),
+			Stderr:          term.ConsoleErr(),
 		})
 		if err != nil {
 			l.opts.Log.Warn("console readline init failed", "err", err)
@@ -158,6 +168,9 @@ func (l *Loop) Start() <-chan struct{} {
 		defer func(rl *readline.Instance) {
 			_ = rl.Close()
 		}(rl)
+		if typed := term.PendingInput(); len(typed) > 0 {
+			_, _ = rl.WriteStdin(typed)
+		}
 		l.opts.Log.Info("console command input enabled")
 		for {
 			line, err := rl.Readline()
@@ -176,11 +189,11 @@ func (l *Loop) Start() <-chan struct{} {
 			}
 			if cmd, found := l.commands[name]; found {
 				if cmd.Execute != nil {
-					cmd.Execute(args)
+					l.run(name, func() bool { cmd.Execute(args); return true })
 				}
 				continue
 			}
-			if l.opts.Resolve != nil && l.opts.Resolve(name, args) {
+			if l.opts.Resolve != nil && l.run(name, func() bool { return l.opts.Resolve(name, args) }) {
 				continue
 			}
 			if l.opts.OnUnknown != nil {
@@ -192,6 +205,18 @@ func (l *Loop) Start() <-chan struct{} {
 	return l.done
 }
 
+func (l *Loop) run(name string, fn func() bool) (handled bool) {
+	if l.opts.OnPanic != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				l.opts.OnPanic(name, r, debug.Stack())
+				handled = true
+			}
+		}()
+	}
+	return fn()
+}
+
 type completer struct {
 	loop *Loop
 }
@@ -296,6 +321,37 @@ func splitCommand(line string) (name, args string, ok bool) {
 	return name, args, true
 }
 
+func SplitArgs(args string) []string {
+	out := make([]string, 0, 4)
+	var cur strings.Builder
+	inQuotes := false
+	hasToken := false
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case c == '\\' && inQuotes && i+1 < len(args):
+			i++
+			cur.WriteByte(args[i])
+		case c == '"':
+			inQuotes = !inQuotes
+			hasToken = true
+		case c == ' ' && !inQuotes:
+			if hasToken {
+				out = append(out, cur.String())
+				cur.Reset()
+				hasToken = false
+			}
+		default:
+			cur.WriteByte(c)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		out = append(out, cur.String())
+	}
+	return out
+}
+
 func matchPrefix(value, prefix string) bool {
 	if prefix == "" {
 		return true
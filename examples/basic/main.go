@@ -19,7 +19,7 @@ func main() {
 		},
 		FieldTransform: consolex.FieldTransformFunc(func(key, value string) (string, bool) {
 			if key == "dimension" {
-				return "\"" + strings.ToUpper(strings.Trim(value, "\"")) + "\"", true
+				return strings.ToUpper(value), true
 			}
 			return "", false
 		}),
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const maxLogLine = 1 << 20
+
+func LogFiles(logPath, archiveDir string) ([]string, error) {
+	logPath, archiveDir = logPaths(logPath, archiveDir)
+	archives, err := filepath.Glob(filepath.Join(archiveDir, "server_*.log.gz"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(archives)
+	if _, err := os.Stat(logPath); err == nil {
+		archives = append(archives, logPath)
+	}
+	return archives, nil
+}
+
+func logPaths(logPath, archiveDir string) (string, string) {
+	logPath = strings.TrimSpace(logPath)
+	if logPath == "" {
+		logPath = "server.log"
+	}
+	archiveDir = strings.TrimSpace(archiveDir)
+	if archiveDir == "" {
+		archiveDir = "logs"
+	}
+	return logPath, archiveDir
+}
+
+func currentLogPaths() (string, string) {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return logPaths(currentCfg.LogFilePath, currentCfg.ArchiveDir)
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g gzipFile) Close() error {
+	_ = g.Reader.Close()
+	return g.f.Close()
+}
+
+func OpenLogFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return gzipFile{Reader: zr, f: f}, nil
+}
+
+func ScanLogFile(path string, fn func(lineNo int, line string) bool) error {
+	rc, err := OpenLogFile(path)
+	if err != nil {
+		return err
+	}
+	defer func(rc io.ReadCloser) {
+		_ = rc.Close()
+	}(rc)
+	sc := bufio.NewScanner(rc)
+	sc.Buffer(make([]byte, 0, 64<<10), maxLogLine)
+	for n := 1; sc.Scan(); n++ {
+		if !fn(n, sc.Text()) {
+			return nil
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type CaptureConfig struct {
+	Component   string
+	Key         string
+	Level       slog.Leveler
+	StderrLevel slog.Leveler
+	Parser      Parser
+	Logger      *slog.Logger
+}
+
+type CaptureWriter struct {
+	cfg   CaptureConfig
+	level slog.Level
+
+	mu    sync.Mutex
+	lines lineBuffer
+}
+
+func NewCaptureWriter(cfg CaptureConfig, level slog.Level) *CaptureWriter {
+	if strings.TrimSpace(cfg.Key) == "" {
+		cfg.Key = "component"
+	}
+	if cfg.Parser == nil {
+		cfg.Parser = AutoParser
+	}
+	return &CaptureWriter{cfg: cfg, level: level}
+}
+
+func AttachCommand(cmd *exec.Cmd, cfg CaptureConfig) (stdout, stderr *CaptureWriter) {
+	if cfg.Component == "" {
+		cfg.Component = strings.TrimSuffix(filepath.Base(cmd.Path), filepath.Ext(cmd.Path))
+	}
+	outLevel, errLevel := slog.LevelInfo, slog.LevelWarn
+	if cfg.Level != nil {
+		outLevel = cfg.Level.Level()
+	}
+	if cfg.StderrLevel != nil {
+		errLevel = cfg.StderrLevel.Level()
+	}
+	stdout = NewCaptureWriter(cfg, outLevel)
+	stderr = NewCaptureWriter(cfg, errLevel)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return stdout, stderr
+}
+
+func (w *CaptureWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(p), w.lines.write(p, w.emit)
+}
+
+func (w *CaptureWriter) ReadFrom(r io.Reader) (int64, error) {
+	buf := make([]byte, 32<<10)
+	var total int64
+	for {
+		n, err := r.Read(buf)
+		total += int64(n)
+		if n > 0 {
+			_, _ = w.Write(buf[:n])
+		}
+		if err == io.EOF {
+			return total, w.Close()
+		}
+		if err != nil {
+			_ = w.Close()
+			return total, err
+		}
+	}
+}
+
+func (w *CaptureWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lines.flush(w.emit)
+}
+
+func (w *CaptureWriter) emit(line string) error {
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	rec, ok := w.cfg.Parser.Parse(line)
+	if !ok {
+		rec, _ = parseRaw(line)
+	}
+	level := w.level
+	name := rec.Level
+	if name == "" {
+		name = InferLevel(rec.Message)
+	}
+	if l, ok := ParseLevel(name); ok {
+		level = l
+	}
+	msg := rec.Message
+	args := make([]any, 0, 2+2*len(rec.Fields))
+	if w.cfg.Component != "" {
+		args = append(args, w.cfg.Key, w.cfg.Component)
+	}
+	for _, f := range rec.Fields {
+		if f.Key == "" {
+			msg = strings.TrimSpace(msg + " " + f.Value)
+			continue
+		}
+		args = append(args, f.Key, f.Value)
+	}
+	logger := w.cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Log(context.Background(), level, msg, args...)
+	return nil
+}
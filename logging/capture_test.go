@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCaptureHelperProcess(t *testing.T) {
+	if os.Getenv("CONSOLEX_CAPTURE_HELPER") != "1" {
+		return
+	}
+	fmt.Print("level=error msg=boom\npartial line")
+	fmt.Fprint(os.Stderr, "warn tail")
+	os.Exit(0)
+}
+
+func TestAttachCommandFlushesTrailingLine(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCaptureHelperProcess$")
+	cmd.Env = append(os.Environ(), "CONSOLEX_CAPTURE_HELPER=1")
+	AttachCommand(cmd, CaptureConfig{Component: "helper", Logger: logger})
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"level=ERROR msg=boom component=helper",
+		`level=INFO msg="partial line" component=helper`,
+		`level=WARN msg="warn tail" component=helper`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,664 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VexoraDevelopment/consolex/cmdline"
+	"github.com/VexoraDevelopment/consolex/style"
+	"github.com/VexoraDevelopment/consolex/term"
+)
+
+var themePreviewLines = []string{
+	`time=2026-01-02T15:04:05.000+00:00 level=DEBUG msg="loading chunk" world=overworld x=12 z=-4`,
+	`time=2026-01-02T15:04:05.120+00:00 level=INFO msg="player joined" player=hub_snow raddr=10.0.0.7:51234`,
+	`time=2026-01-02T15:04:06.004+00:00 level=WARN msg="tick took too long" elapsed=87ms`,
+	`time=2026-01-02T15:04:07.310+00:00 level=ERROR msg="conn write packet failed" err="context canceled"`,
+}
+
+func ThemeCommand(out io.Writer) cmdline.Command {
+	if out == nil {
+		out = term.ConsoleOut()
+	}
+	return cmdline.Command{
+		Name:        "theme",
+		Description: "List, preview and switch log themes",
+		Execute: func(args string) {
+			runThemeCommand(out, strings.Fields(args))
+		},
+		Complete: func(argPos int, prefix string) []string {
+			switch argPos {
+			case 0:
+				return []string{"list", "preview", "use", "load", "save"}
+			case 1:
+				return style.ThemeNames()
+			}
+			return nil
+		},
+	}
+}
+
+func resolveTheme(nameOrPath string) (style.Theme, error) {
+	if t, ok := style.LookupTheme(nameOrPath); ok {
+		return t, nil
+	}
+	if _, err := os.Stat(nameOrPath); os.IsNotExist(err) {
+		return style.Theme{}, fmt.Errorf("unknown theme %q (available: %s)", nameOrPath, strings.Join(style.ThemeNames(), ", "))
+	}
+	return style.LoadTheme(nameOrPath)
+}
+
+func runThemeCommand(out io.Writer, args []string) {
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "list":
+		current := CurrentTheme().Name
+		for _, name := range style.ThemeNames() {
+			t, _ := style.LookupTheme(name)
+			mark := " "
+			if name == current {
+				mark = "*"
+			}
+			_, _ = fmt.Fprintf(out, "%s %-12s %s %s %s %s\n", mark, name,
+				t.Debug.Wrap(" DBG "), t.Info.Wrap(" INF "), t.Warn.Wrap(" WRN "), t.Error.Wrap(" ERR "))
+		}
+	case "preview":
+		t := CurrentTheme()
+		if len(args) > 1 {
+			var err error
+			if t, err = resolveTheme(args[1]); err != nil {
+				_, _ = fmt.Fprintf(out, "theme: %v\n", err)
+				return
+			}
+		}
+		lines, err := previewTheme(t, themePreviewLines)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "theme: %v\n", err)
+			return
+		}
+		for _, line := range lines {
+			_, _ = fmt.Fprintln(out, line)
+		}
+	case "use":
+		if len(args) < 2 {
+			_, _ = fmt.Fprintln(out, "usage: theme use <name|path>")
+			return
+		}
+		t, err := resolveTheme(args[1])
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "theme: %v\n", err)
+			return
+		}
+		if _, ok := style.LookupTheme(t.Name); !ok {
+			style.RegisterTheme(t.Name, t)
+			t, _ = style.LookupTheme(t.Name)
+		}
+		SetTheme(t)
+		_, _ = fmt.Fprintf(out, "theme: using %s\n", t.Name)
+	case "load":
+		if len(args) < 2 {
+			_, _ = fmt.Fprintln(out, "usage: theme load <path>")
+			return
+		}
+		t, err := style.LoadTheme(args[1])
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "theme: %v\n", err)
+			return
+		}
+		style.RegisterTheme(t.Name, t)
+		_, _ = fmt.Fprintf(out, "theme: loaded %s\n", t.Name)
+	case "save":
+		if len(args) < 2 {
+			_, _ = fmt.Fprintln(out, "usage: theme save <path> [name]")
+			return
+		}
+		t := CurrentTheme()
+		if len(args) > 2 {
+			var err error
+			if t, err = resolveTheme(args[2]); err != nil {
+				_, _ = fmt.Fprintf(out, "theme: %v\n", err)
+				return
+			}
+		}
+		if err := style.SaveTheme(args[1], t); err != nil {
+			_, _ = fmt.Fprintf(out, "theme: %v\n", err)
+			return
+		}
+		_, _ = fmt.Fprintf(out, "theme: saved to %s\n", args[1])
+	default:
+		_, _ = fmt.Fprintln(out, "usage: theme [list|preview [name]|use <name|path>|load <path>|save <path> [name]]")
+	}
+}
+
+func LevelCommand(out io.Writer) cmdline.Command {
+	if out == nil {
+		out = term.ConsoleOut()
+	}
+	return cmdline.Command{
+		Name:        "level",
+		Aliases:     []string{"loglevel"},
+		Description: "Show or change the minimum log level",
+		Execute: func(args string) {
+			args = strings.TrimSpace(args)
+			if args == "" {
+				_, _ = fmt.Fprintf(out, "level: %s\n", LevelName(CurrentLevel()))
+				names := make([]string, 0, len(Levels()))
+				for _, spec := range Levels() {
+					names = append(names, fmt.Sprintf("%s(%d)", spec.Name, spec.Value))
+				}
+				_, _ = fmt.Fprintf(out, "available: %s\n", strings.Join(names, " "))
+				return
+			}
+			l, ok := ParseLevel(args)
+			if !ok {
+				_, _ = fmt.Fprintf(out, "level: unknown level %q\n", args)
+				return
+			}
+			SetLevel(l)
+			_, _ = fmt.Fprintf(out, "level: set to %s\n", LevelName(l))
+		},
+		Complete: func(argPos int, prefix string) []string {
+			if argPos != 0 {
+				return nil
+			}
+			out := make([]string, 0, len(Levels()))
+			for _, spec := range Levels() {
+				out = append(out, strings.ToLower(spec.Name))
+			}
+			return out
+		},
+	}
+}
+
+func HighlightCommand(out io.Writer, set *HighlightSet) cmdline.Command {
+	if out == nil {
+		out = term.ConsoleOut()
+	}
+	if set == nil {
+		set = highlights
+	}
+	return cmdline.Command{
+		Name:        "highlight",
+		Aliases:     []string{"hl"},
+		Description: "Manage highlight rules for log text",
+		Execute: func(args string) {
+			runHighlightCommand(out, set, cmdline.SplitArgs(args))
+		},
+		Complete: func(argPos int, prefix string) []string {
+			if argPos == 0 {
+				return []string{"add", "remove", "list", "clear"}
+			}
+			return nil
+		},
+	}
+}
+
+func runHighlightCommand(out io.Writer, set *HighlightSet, args []string) {
+	sub := "list"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "list":
+		rules := set.Rules()
+		if len(rules) == 0 {
+			_, _ = fmt.Fprintln(out, "highlight: no rules")
+			return
+		}
+		for i, r := range rules {
+			pattern := strconv.Quote(r.Pattern)
+			if r.Regex {
+				pattern = "/" + r.Pattern + "/"
+			}
+			_, _ = fmt.Fprintf(out, "%2d. %s %s priority=%d\n", i+1, r.Style.Wrap(pattern), r.Style.Spec(), r.Priority)
+		}
+	case "add":
+		rule, err := parseHighlightArgs(args[1:])
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "highlight: %v\n", err)
+			return
+		}
+		if err := set.Add(rule); err != nil {
+			_, _ = fmt.Fprintf(out, "highlight: %v\n", err)
+			return
+		}
+		_, _ = fmt.Fprintf(out, "highlight: added %s\n", rule.Style.Wrap(rule.Pattern))
+	case "remove", "rm":
+		if len(args) < 2 {
+			_, _ = fmt.Fprintln(out, "usage: highlight remove <pattern|number>")
+			return
+		}
+		pattern := strings.Trim(args[1], "/")
+		if n, err := strconv.Atoi(args[1]); err == nil {
+			if rules := set.Rules(); n >= 1 && n <= len(rules) {
+				pattern = rules[n-1].Pattern
+			}
+		}
+		if !set.Remove(pattern) {
+			_, _ = fmt.Fprintf(out, "highlight: no rule %q\n", args[1])
+			return
+		}
+		_, _ = fmt.Fprintf(out, "highlight: removed %q\n", pattern)
+	case "clear":
+		set.Clear()
+		_, _ = fmt.Fprintln(out, "highlight: cleared")
+	default:
+		_, _ = fmt.Fprintln(out, "usage: highlight [list|add <text|/regex/> [style...] [-i] [--priority N]|remove <pattern|number>|clear]")
+	}
+}
+
+func parseHighlightArgs(args []string) (HighlightRule, error) {
+	if len(args) == 0 || args[0] == "" {
+		return HighlightRule{}, fmt.Errorf("missing pattern")
+	}
+	rule := HighlightRule{Pattern: args[0]}
+	if p := args[0]; len(p) >= 2 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+		rule.Pattern = p[1 : len(p)-1]
+		rule.Regex = true
+	}
+	spec := make([]string, 0, len(args))
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "-i", "--ignore-case":
+			rule.IgnoreCase = true
+		case "-p", "--priority":
+			if i+1 >= len(args) {
+				return HighlightRule{}, fmt.Errorf("missing priority value")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return HighlightRule{}, fmt.Errorf("bad priority %q", args[i+1])
+			}
+			rule.Priority = n
+			i++
+		default:
+			spec = append(spec, args[i])
+		}
+	}
+	if len(spec) == 0 {
+		spec = []string{"bold", "yellow"}
+	}
+	st, err := style.ParseStyle(strings.Join(spec, " "))
+	if err != nil {
+		return HighlightRule{}, err
+	}
+	rule.Style = st
+	return rule, nil
+}
+
+func LegendCommand(out io.Writer, provider *HashColorProvider) cmdline.Command {
+	if out == nil {
+		out = term.ConsoleOut()
+	}
+	return cmdline.Command{
+		Name:        "legend",
+		Description: "Show colours assigned to tracked field values",
+		Execute: func(args string) {
+			key := strings.TrimSpace(args)
+			list := provider.Assignments(key)
+			if len(list) == 0 {
+				_, _ = fmt.Fprintln(out, "legend: no values tracked yet")
+				return
+			}
+			current := ""
+			for _, a := range list {
+				if a.Key != current {
+					current = a.Key
+					_, _ = fmt.Fprintf(out, "%s:\n", current)
+				}
+				_, _ = fmt.Fprintf(out, "  %s\n", a.Style.Wrap(a.Value))
+			}
+		},
+		Complete: func(argPos int, prefix string) []string {
+			if argPos == 0 {
+				return provider.Keys()
+			}
+			return nil
+		},
+	}
+}
+
+const (
+	defaultTailLines = 20
+	defaultGrepLimit = 50
+)
+
+type logsMatch struct {
+	where string
+	rec   *LogRecord
+}
+
+func LogsCommand(out io.Writer) cmdline.Command {
+	if out == nil {
+		out = term.ConsoleOut()
+	}
+	return cmdline.Command{
+		Name:        "logs",
+		Description: "Tail, search and inspect recent log records",
+		Execute: func(args string) {
+			runLogsCommand(out, cmdline.SplitArgs(args))
+		},
+		Complete: func(argPos int, prefix string) []string {
+			if argPos == 0 {
+				return []string{"tail", "grep", "show"}
+			}
+			return []string{"--level", "--since", "--limit"}
+		},
+	}
+}
+
+func runLogsCommand(out io.Writer, args []string) {
+	sub := "tail"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+	switch sub {
+	case "tail":
+		n := defaultTailLines
+		if len(args) > 0 {
+			v, err := strconv.Atoi(args[0])
+			if err != nil || v <= 0 {
+				_, _ = fmt.Fprintln(out, "usage: logs tail [n]")
+				return
+			}
+			n = v
+		}
+		printLogMatches(out, tailLogs(n))
+	case "grep":
+		q, limit, err := parseLogsQuery(args)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "logs: %v\n", err)
+			_, _ = fmt.Fprintln(out, "usage: logs grep <pattern> [--term word] [--level warn] [--since 10m] [--limit n]")
+			return
+		}
+		matches := grepLogs(q)
+		if len(matches) > limit {
+			_, _ = fmt.Fprintf(out, "logs: %d matches, showing the last %d\n", len(matches), limit)
+			matches = matches[len(matches)-limit:]
+		}
+		if len(matches) == 0 {
+			_, _ = fmt.Fprintln(out, "logs: no matches")
+			return
+		}
+		printLogMatches(out, matches)
+	case "show":
+		if len(args) == 0 {
+			_, _ = fmt.Fprintln(out, "usage: logs show <id>")
+			return
+		}
+		seq, err := strconv.ParseUint(strings.TrimPrefix(args[0], "#"), 10, 64)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "logs: bad id %q\n", args[0])
+			return
+		}
+		e, ok := recent.Get(seq)
+		if !ok {
+			_, _ = fmt.Fprintf(out, "logs: record #%d is no longer in memory\n", seq)
+			return
+		}
+		showLogRecord(out, e)
+	default:
+		_, _ = fmt.Fprintln(out, "usage: logs [tail [n]|grep <pattern> [--term w] [--level l] [--since d] [--limit n]|show <id>]")
+	}
+}
+
+func parseLogsQuery(args []string) (RecordQuery, int, error) {
+	q := RecordQuery{}
+	limit := defaultGrepLimit
+	pattern := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			if pattern != "" {
+				return q, 0, fmt.Errorf("unexpected argument %q", arg)
+			}
+			pattern = arg
+			continue
+		}
+		if i+1 >= len(args) {
+			return q, 0, fmt.Errorf("missing value for %s", arg)
+		}
+		value := args[i+1]
+		i++
+		switch arg {
+		case "--level":
+			l, ok := ParseLevel(value)
+			if !ok {
+				return q, 0, fmt.Errorf("unknown level %q", value)
+			}
+			q.MinLevel = l
+		case "--since":
+			t, err := parseSince(value)
+			if err != nil {
+				return q, 0, err
+			}
+			q.Since = t
+		case "--limit":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return q, 0, fmt.Errorf("bad limit %q", value)
+			}
+			limit = n
+		case "--term":
+			q.Terms = append(q.Terms, value)
+		default:
+			return q, 0, fmt.Errorf("unknown flag %s", arg)
+		}
+	}
+	if pattern == "" {
+		if len(q.Terms) == 0 {
+			return q, 0, fmt.Errorf("missing pattern")
+		}
+		return q, limit, nil
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+	}
+	q.Pattern = re
+	return q, limit, nil
+}
+
+func parseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad --since %q", value)
+}
+
+func tailLogs(n int) []logsMatch {
+	entries := recent.Tail(n)
+	out := ringMatches(entries)
+	need := n - len(entries)
+	if need <= 0 {
+		return out
+	}
+	logPath, archiveDir := currentLogPaths()
+	files, err := LogFiles(logPath, archiveDir)
+	if err != nil {
+		return out
+	}
+	older := olderThanRing()
+	for i := len(files) - 1; i >= 0 && need > 0; i-- {
+		var lines []logsMatch
+		_ = ScanLogFile(files[i], func(lineNo int, line string) bool {
+			e := fileRingRecord(line)
+			if !older(e) {
+				return true
+			}
+			lines = append(lines, logsMatch{where: fileRef(files[i], lineNo), rec: e.Record})
+			if len(lines) > need {
+				lines = lines[1:]
+			}
+			return true
+		})
+		need -= len(lines)
+		out = append(lines, out...)
+	}
+	return out
+}
+
+func grepLogs(q RecordQuery) []logsMatch {
+	matches := ringMatches(recent.Query(q))
+	if before, ok := recent.oldestTime(); ok && !q.Since.IsZero() && !q.Since.Before(before) {
+		return matches
+	}
+	logPath, archiveDir := currentLogPaths()
+	files, err := LogFiles(logPath, archiveDir)
+	if err != nil {
+		return matches
+	}
+	older := olderThanRing()
+	files, _ = PruneLogFiles(files, q)
+	var found []logsMatch
+	for _, path := range files {
+		_ = ScanLogFile(path, func(lineNo int, line string) bool {
+			if e := fileRingRecord(line); older(e) && q.Match(e) {
+				found = append(found, logsMatch{where: fileRef(path, lineNo), rec: e.Record})
+			}
+			return true
+		})
+	}
+	return append(found, matches...)
+}
+
+func olderThanRing() func(RingRecord) bool {
+	before, ok := recent.oldestTime()
+	if !ok {
+		return func(RingRecord) bool { return true }
+	}
+	inRing := map[string]bool{}
+	for _, e := range recent.Tail(0) {
+		if e.Time.Equal(before) {
+			inRing[e.Record.Raw] = true
+		}
+	}
+	return func(e RingRecord) bool {
+		return e.Time.Before(before) || e.Time.Equal(before) && !inRing[e.Record.Raw]
+	}
+}
+
+func fileRingRecord(line string) RingRecord {
+	rec := ParseTextLogLine(line)
+	e := RingRecord{Seq: ^uint64(0), Record: rec}
+	e.Time, _ = recordTime(rec)
+	if l, ok := ParseLevel(rec.Level); ok {
+		e.Level = l
+	}
+	return e
+}
+
+func ringMatches(entries []RingRecord) []logsMatch {
+	out := make([]logsMatch, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, logsMatch{where: "#" + strconv.FormatUint(e.Seq, 10), rec: e.Record})
+	}
+	return out
+}
+
+func fileRef(path string, lineNo int) string {
+	return filepath.Base(path) + ":" + strconv.Itoa(lineNo)
+}
+
+func printLogMatches(out io.Writer, matches []logsMatch) {
+	muted := CurrentTheme().Style("muted")
+	pl := currentPipeline().Fork()
+	for _, m := range matches {
+		_, _ = fmt.Fprintf(out, "%s %s\n", muted.Wrap(m.where), pl.Render(m.rec))
+	}
+}
+
+func showLogRecord(out io.Writer, e RingRecord) {
+	theme := CurrentTheme()
+	key := theme.Style("field.key")
+	_, _ = fmt.Fprintf(out, "%s %s\n", theme.Style("muted").Wrap("#"+strconv.FormatUint(e.Seq, 10)), ColorizeRecord(e.Record.Clone()))
+	_, _ = fmt.Fprintf(out, "  %s %s\n", key.Wrap("time "), e.Time.Format(time.RFC3339Nano))
+	_, _ = fmt.Fprintf(out, "  %s %s\n", key.Wrap("level"), LevelName(e.Level))
+	_, _ = fmt.Fprintf(out, "  %s %s\n", key.Wrap("msg  "), e.Record.Message)
+	for _, f := range e.Record.Fields {
+		name := f.Key
+		if name == "" {
+			name = "(bare)"
+		}
+		_, _ = fmt.Fprintf(out, "  %s = %s %s\n", key.Wrap(name), f.textValue(), theme.Style("muted").Wrap(f.Kind.String()))
+	}
+	_, _ = fmt.Fprintf(out, "  %s %s\n", key.Wrap("raw  "), e.Record.Raw)
+}
+
+const statsTopMessages = 10
+
+func StatsCommand(out io.Writer) cmdline.Command {
+	if out == nil {
+		out = term.ConsoleOut()
+	}
+	return cmdline.Command{
+		Name:        "stats",
+		Description: "Show log record counters",
+		Execute: func(args string) {
+			switch strings.ToLower(strings.TrimSpace(args)) {
+			case "":
+				printStats(out, metrics.Snapshot())
+			case "reset":
+				metrics.Reset()
+				_, _ = fmt.Fprintln(out, "stats: counters reset")
+			default:
+				_, _ = fmt.Fprintln(out, "usage: stats [reset]")
+			}
+		},
+		Complete: func(argPos int, prefix string) []string {
+			if argPos == 0 {
+				return []string{"reset"}
+			}
+			return nil
+		},
+	}
+}
+
+func printStats(out io.Writer, s MetricsSnapshot) {
+	theme := CurrentTheme()
+	key := theme.Style("field.key")
+	muted := theme.Style("muted")
+	_, _ = fmt.Fprintf(out, "%s %s %s\n", key.Wrap("since      "), s.Since.Format(time.DateTime), muted.Wrap("("+time.Since(s.Since).Round(time.Second).String()+")"))
+	levels := make([]string, 0, len(s.Levels))
+	for _, spec := range Levels() {
+		levels = append(levels, fmt.Sprintf("%s %d", spec.Name, s.Levels[spec.Name]))
+	}
+	_, _ = fmt.Fprintf(out, "%s %s\n", key.Wrap("levels     "), strings.Join(levels, "  "))
+	_, _ = fmt.Fprintf(out, "%s %s\n", key.Wrap("components "), formatCounts(s.Components))
+	_, _ = fmt.Fprintf(out, "%s %s\n", key.Wrap("suppressed "), formatCounts(s.Suppressed))
+	_, _ = fmt.Fprintf(out, "%s %s\n", key.Wrap("dropped    "), formatCounts(s.Dropped))
+	_, _ = fmt.Fprintf(out, "%s %s\n", key.Wrap("sink errors"), formatCounts(s.SinkErrors))
+	if len(s.Messages) > 0 {
+		_, _ = fmt.Fprintf(out, "%s\n", key.Wrap("top messages"))
+		for _, msg := range topCounts(s.Messages, statsTopMessages) {
+			_, _ = fmt.Fprintf(out, "  %8d  %s\n", s.Messages[msg], msg)
+		}
+	}
+	_, _ = fmt.Fprintf(out, "%s %s\n", key.Wrap("summary    "), summaryOf(s))
+}
+
+func formatCounts(counts map[string]uint64) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(counts))
+	for _, k := range topCounts(counts, 0) {
+		parts = append(parts, fmt.Sprintf("%s %d", k, counts[k]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+func TestTailLogsFillsFromOlderFiles(t *testing.T) {
+	dir := t.TempDir()
+	line := func(i int) string {
+		return fmt.Sprintf("time=2026-01-02T15:04:%02d.000Z level=INFO msg=m%d", i, i)
+	}
+	var lines []string
+	for i := 1; i <= 6; i++ {
+		lines = append(lines, line(i))
+	}
+	logPath := filepath.Join(dir, "server.log")
+	if err := os.WriteFile(logPath, []byte(strings.Join(lines[:4], "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stateMu.Lock()
+	oldCfg := currentCfg
+	currentCfg.LogFilePath, currentCfg.ArchiveDir = logPath, filepath.Join(dir, "logs")
+	stateMu.Unlock()
+	oldRing := recent
+	recent = NewRecordRing(8)
+	defer func() {
+		stateMu.Lock()
+		currentCfg = oldCfg
+		stateMu.Unlock()
+		recent = oldRing
+	}()
+	for _, l := range lines[3:] {
+		recent.Add(ParseTextLogLine(l))
+	}
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{1, []string{"m6"}},
+		{3, []string{"m4", "m5", "m6"}},
+		{4, []string{"m3", "m4", "m5", "m6"}},
+		{10, []string{"m1", "m2", "m3", "m4", "m5", "m6"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, m := range tailLogs(tt.n) {
+			got = append(got, m.rec.Message)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("tailLogs(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestThemeCommandReportsLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"use", "no-such-theme"}, `unknown theme "no-such-theme"`},
+		{[]string{"use", bad}, bad + ": "},
+		{[]string{"preview", bad}, bad + ": "},
+		{[]string{"save", filepath.Join(dir, "out.toml"), bad}, bad + ": "},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		runThemeCommand(&b, tt.args)
+		if got := b.String(); !strings.Contains(got, tt.want) {
+			t.Errorf("theme %v: output %q, want it to contain %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestThemePreviewUsesConfiguredPipeline(t *testing.T) {
+	stateMu.Lock()
+	oldCfg := currentCfg
+	currentCfg.Format = "<{level:label}> {msg}"
+	stateMu.Unlock()
+	defer func() {
+		stateMu.Lock()
+		currentCfg = oldCfg
+		stateMu.Unlock()
+	}()
+	var b strings.Builder
+	runThemeCommand(&b, []string{"preview", "default"})
+	if got := style.StripANSI(b.String()); !strings.Contains(got, "<INF> player joined") {
+		t.Fatalf("preview ignored the configured format:\n%s", got)
+	}
+}
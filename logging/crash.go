@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VexoraDevelopment/consolex/style"
+	"github.com/VexoraDevelopment/consolex/term"
+)
+
+func HandleCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	_, _ = ReportCrash(r, debug.Stack())
+	os.Exit(2)
+}
+
+func ReportCommandPanic(name string, recovered any, stack []byte) {
+	_, _ = reportCrash(recovered, stack, "command", name)
+}
+
+func ReportCrash(recovered any, stack []byte) (string, error) {
+	return reportCrash(recovered, stack)
+}
+
+func reportCrash(recovered any, stack []byte, attrs ...any) (string, error) {
+	stateMu.RLock()
+	dir := strings.TrimSpace(currentCfg.ArchiveDir)
+	theme := currentTheme
+	stateMu.RUnlock()
+	if dir == "" {
+		dir = "logs"
+	}
+	site := panicSite()
+
+	var b bytes.Buffer
+	writeCrashReport(&b, recovered, stack, site, attrs)
+	path, err := writeCrashFile(dir, time.Now(), b.Bytes())
+
+	args := append([]any{"at", site}, attrs...)
+	if path != "" {
+		args = append(args, "report", path)
+	} else {
+		args = append(args, "err", err)
+	}
+	slog.Default().Log(context.Background(), LevelFatal, fmt.Sprintf("panic: %v", recovered), args...)
+	flushSinks()
+	printCrashSummary(term.ConsoleErr(), theme, recovered, site, path, err)
+	return path, err
+}
+
+func writeCrashFile(dir string, now time.Time, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	base := filepath.Join(dir, "crash_"+now.Format("2006-01-02_15-04-05"))
+	for n := 1; ; n++ {
+		path := base + ".txt"
+		if n > 1 {
+			path = base + "_" + strconv.Itoa(n) + ".txt"
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) && n < 1000 {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = f.Write(data)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+}
+
+func writeCrashReport(w io.Writer, recovered any, stack []byte, site string, attrs []any) {
+	_, _ = fmt.Fprintf(w, "crash report %s\n\n", time.Now().Format(time.RFC3339Nano))
+	_, _ = fmt.Fprintf(w, "panic: %v\n", recovered)
+	_, _ = fmt.Fprintf(w, "type:  %T\n", recovered)
+	if site != "" {
+		_, _ = fmt.Fprintf(w, "at:    %s\n", site)
+	}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		_, _ = fmt.Fprintf(w, "%v: %v\n", attrs[i], attrs[i+1])
+	}
+	_, _ = fmt.Fprintf(w, "pid:   %d\n", os.Getpid())
+	_, _ = fmt.Fprintf(w, "go:    %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		_, _ = fmt.Fprintf(w, "main:  %s %s\n", info.Main.Path, info.Main.Version)
+		for _, s := range info.Settings {
+			if strings.HasPrefix(s.Key, "vcs") {
+				_, _ = fmt.Fprintf(w, "%s: %s\n", s.Key, s.Value)
+			}
+		}
+	}
+
+	records := recent.Tail(0)
+	_, _ = fmt.Fprintf(w, "\n--- last %d log records ---\n", len(records))
+	for _, e := range records {
+		_, _ = fmt.Fprintf(w, "#%d %s\n", e.Seq, e.Record.Raw)
+	}
+	if len(stack) > 0 {
+		_, _ = fmt.Fprintf(w, "\n--- panic stack ---\n%s", stack)
+	}
+	_, _ = fmt.Fprintf(w, "\n--- goroutines ---\n%s", allStacks())
+}
+
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= 64<<20 {
+			return buf[:n]
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+}
+
+func panicSite() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	panicking := false
+	for {
+		f, more := frames.Next()
+		switch {
+		case f.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && !strings.HasPrefix(f.Function, "runtime."):
+			return f.Function + " " + filepath.Base(f.File) + ":" + strconv.Itoa(f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func printCrashSummary(w io.Writer, theme style.Theme, recovered any, site, path string, err error) {
+	badge, ok := theme.Lookup("level.fatal")
+	if !ok {
+		badge = theme.Style("level.error")
+	}
+	muted := theme.Style("muted")
+	_, _ = fmt.Fprintf(w, "\n%s %s\n", badge.Wrap(" CRASH "), theme.Style("msg").Bold().Wrap(fmt.Sprintf("panic: %v", recovered)))
+	if site != "" {
+		_, _ = fmt.Fprintf(w, "        %s %s\n", muted.Wrap("at"), site)
+	}
+	if path != "" {
+		_, _ = fmt.Fprintf(w, "        %s %s\n", muted.Wrap("report"), path)
+	} else if err != nil {
+		_, _ = fmt.Fprintf(w, "        %s %v\n", muted.Wrap("report failed:"), err)
+	}
+}
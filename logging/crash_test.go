@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCrashFileKeepsSameSecondReports(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	want := []string{"crash_2026-01-02_15-04-05.txt", "crash_2026-01-02_15-04-05_2.txt", "crash_2026-01-02_15-04-05_3.txt"}
+	for i, name := range want {
+		path, err := writeCrashFile(dir, now, []byte{byte('a' + i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != filepath.Join(dir, name) {
+			t.Fatalf("report %d written to %s, want %s", i, path, name)
+		}
+	}
+	for i, name := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != string(rune('a'+i)) {
+			t.Fatalf("%s = %q, %v", name, data, err)
+		}
+	}
+}
+
+func TestReportCrashFlushesDedupedSinks(t *testing.T) {
+	var buf bytes.Buffer
+	agg := NewAggregateLineWriter(&buf, time.Hour, nil, nil)
+	prev := slog.Default()
+	stateMu.Lock()
+	oldCfg, oldAggs := currentCfg, aggregators
+	currentCfg.ArchiveDir = t.TempDir()
+	aggregators = []*AggregateLineWriter{agg}
+	stateMu.Unlock()
+	t.Cleanup(func() {
+		slog.SetDefault(prev)
+		stateMu.Lock()
+		currentCfg, aggregators = oldCfg, oldAggs
+		stateMu.Unlock()
+	})
+	slog.SetDefault(slog.New(slog.NewTextHandler(agg, nil)))
+
+	slog.Info("before crash")
+	if _, err := ReportCrash("boom", []byte("stack")); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, `msg="before crash"`) || !strings.Contains(got, `msg="panic: boom"`) {
+		t.Fatalf("pending lines not flushed before exit:\n%s", got)
+	}
+}
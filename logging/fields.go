@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+const fieldEllipsis = "…"
+
+func arrangeFields(fields []RecordField, p Profile) ([]RecordField, int) {
+	out := make([]RecordField, 0, len(fields))
+	for _, f := range fields {
+		if p.DropEmpty && isEmptyValue(f) {
+			continue
+		}
+		if limit := maxValueLen(p, f.Key); limit > 0 {
+			f = elideField(f, limit)
+		}
+		out = append(out, f)
+	}
+	if len(p.FieldOrder) > 0 || len(p.FieldLast) > 0 {
+		rank := make(map[string]int, len(p.FieldOrder)+len(p.FieldLast))
+		for i, key := range p.FieldOrder {
+			rank[key] = i - len(p.FieldOrder)
+		}
+		for i, key := range p.FieldLast {
+			rank[key] = i + 1
+		}
+		sort.SliceStable(out, func(i, j int) bool { return rank[out[i].Key] < rank[out[j].Key] })
+	}
+	if p.MaxFields > 0 && len(out) > p.MaxFields {
+		return out[:p.MaxFields], len(out) - p.MaxFields
+	}
+	return out, 0
+}
+
+func isEmptyValue(f RecordField) bool {
+	v := f.Value
+	if f.ValueOut != "" {
+		v = style.StripANSI(f.ValueOut)
+	}
+	return v == "" || v == `""`
+}
+
+func maxValueLen(p Profile, key string) int {
+	if n, ok := p.MaxValueLen[key]; ok {
+		return n
+	}
+	return p.MaxValueLen["*"]
+}
+
+func elideField(f RecordField, limit int) RecordField {
+	if v := f.displayValue(); style.Width(v) > limit {
+		f.ValueOut = style.TruncateMiddle(v, limit, fieldEllipsis)
+	}
+	return f
+}
+
+func moreFieldsMarker(n int) string {
+	return "+" + strconv.Itoa(n) + " more"
+}
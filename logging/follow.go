@@ -0,0 +1,221 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+const defaultFollowPoll = 250 * time.Millisecond
+
+type LogSource struct {
+	Name string
+	R    io.Reader
+}
+
+type LogLine struct {
+	Source string
+	LineNo int
+	Time   time.Time
+	Record *LogRecord
+}
+
+type mergeCursor struct {
+	name   string
+	sc     *bufio.Scanner
+	lineNo int
+	last   time.Time
+	line   LogLine
+	ok     bool
+}
+
+func (c *mergeCursor) advance(parser Parser) error {
+	if !c.sc.Scan() {
+		c.ok = false
+		return c.sc.Err()
+	}
+	c.lineNo++
+	rec := parseWith(parser, c.sc.Text())
+	if t, ok := recordTime(rec); ok {
+		c.last = t
+	}
+	c.line = LogLine{Source: c.name, LineNo: c.lineNo, Time: c.last, Record: rec}
+	c.ok = true
+	return nil
+}
+
+func MergeLogs(sources []LogSource, parser Parser, fn func(LogLine) bool) error {
+	cursors := make([]*mergeCursor, 0, len(sources))
+	var errs []error
+	for _, src := range sources {
+		sc := bufio.NewScanner(src.R)
+		sc.Buffer(make([]byte, 0, 64<<10), maxLogLine)
+		c := &mergeCursor{name: src.Name, sc: sc}
+		if err := c.advance(parser); err != nil {
+			errs = append(errs, err)
+		}
+		cursors = append(cursors, c)
+	}
+	for {
+		var next *mergeCursor
+		for _, c := range cursors {
+			if c.ok && (next == nil || c.line.Time.Before(next.line.Time)) {
+				next = c
+			}
+		}
+		if next == nil {
+			return errors.Join(errs...)
+		}
+		if !fn(next.line) {
+			return errors.Join(errs...)
+		}
+		if err := next.advance(parser); err != nil {
+			errs = append(errs, err)
+		}
+	}
+}
+
+func MergeLogFiles(paths []string, parser Parser, fn func(LogLine) bool) error {
+	sources := make([]LogSource, 0, len(paths))
+	for _, path := range paths {
+		rc, err := OpenLogFile(path)
+		if err != nil {
+			return err
+		}
+		defer func(rc io.ReadCloser) {
+			_ = rc.Close()
+		}(rc)
+		sources = append(sources, LogSource{Name: path, R: rc})
+	}
+	return MergeLogs(sources, parser, fn)
+}
+
+func FollowLogFile(ctx context.Context, path string, offset int64, fn func(line string)) error {
+	var (
+		f     *os.File
+		info  os.FileInfo
+		prev  os.FileInfo
+		mark  []byte
+		lines lineBuffer
+	)
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+	emit := func(line string) error {
+		fn(line)
+		return nil
+	}
+	buf := make([]byte, 64<<10)
+	ticker := time.NewTicker(defaultFollowPoll)
+	defer ticker.Stop()
+	for {
+		cur, err := os.Stat(path)
+		switch {
+		case err != nil && !os.IsNotExist(err):
+			return err
+		case err == nil && (f == nil || !os.SameFile(info, cur) || cur.Size() < offset || rewritten(f, prev, cur, offset, mark)):
+			if f != nil {
+				_ = lines.flush(emit)
+				_ = f.Close()
+				f, offset = nil, 0
+			}
+			if f, err = os.Open(path); err != nil {
+				return err
+			}
+			if info, err = f.Stat(); err != nil {
+				return err
+			}
+			cur = info
+			if info.Size() < offset {
+				offset = 0
+			}
+			if mark, err = readMark(f, offset); err != nil {
+				return err
+			}
+		}
+		if err == nil {
+			prev = cur
+		}
+		if f != nil {
+			for {
+				n, err := f.ReadAt(buf, offset)
+				offset += int64(n)
+				mark = appendMark(mark, buf[:n])
+				_ = lines.write(buf[:n], emit)
+				if err == io.EOF || n == 0 {
+					break
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			_ = lines.flush(emit)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+const followMarkSize = 64
+
+func rewritten(f *os.File, prev, cur os.FileInfo, offset int64, mark []byte) bool {
+	if prev == nil || !os.SameFile(prev, cur) || cur.ModTime().Equal(prev.ModTime()) && cur.Size() == prev.Size() {
+		return false
+	}
+	got, err := readMark(f, offset)
+	return err != nil || !bytes.Equal(got, mark)
+}
+
+func readMark(f *os.File, offset int64) ([]byte, error) {
+	n := min(offset, followMarkSize)
+	mark := make([]byte, n)
+	if _, err := f.ReadAt(mark, offset-n); err != nil {
+		return nil, err
+	}
+	return mark, nil
+}
+
+func appendMark(mark, p []byte) []byte {
+	mark = append(mark, p...)
+	if len(mark) > followMarkSize {
+		mark = append(mark[:0], mark[len(mark)-followMarkSize:]...)
+	}
+	return mark
+}
+
+func (q RecordQuery) MatchRecord(rec *LogRecord) bool {
+	if rec == nil {
+		return false
+	}
+	e := RingRecord{Seq: ^uint64(0), Record: rec}
+	e.Time, _ = recordTime(rec)
+	if l, ok := ParseLevel(rec.Level); ok {
+		e.Level = l
+	}
+	return q.Match(e)
+}
+
+func recordTime(rec *LogRecord) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, rec.Time)
+	return t, err == nil
+}
+
+func parseWith(parser Parser, line string) *LogRecord {
+	if parser == nil {
+		return ParseTextLogLine(line)
+	}
+	if rec, ok := parser.Parse(line); ok {
+		return rec
+	}
+	rec, _ := parseRaw(line)
+	return rec
+}
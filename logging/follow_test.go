@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFollowLogFileDetectsRewrites(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T, path string)
+	}{
+		{
+			name: "truncated and regrown in place",
+			change: func(t *testing.T, path string) {
+				if err := os.WriteFile(path, []byte("level=INFO msg=new-a\nlevel=INFO msg=new-b\nlevel=INFO msg=new-c\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "rotated and regrown",
+			change: func(t *testing.T, path string) {
+				if err := os.Rename(path, path+".1"); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("level=INFO msg=new-a\nlevel=INFO msg=new-b\nlevel=INFO msg=new-c\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "server.log")
+			if err := os.WriteFile(path, []byte("level=INFO msg=old-1\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var (
+				mu  sync.Mutex
+				got []string
+			)
+			seen := func() string {
+				mu.Lock()
+				defer mu.Unlock()
+				return strings.Join(got, ",")
+			}
+			done := make(chan error, 1)
+			go func() {
+				done <- FollowLogFile(ctx, path, 0, func(line string) {
+					mu.Lock()
+					got = append(got, ParseTextLogLine(line).Message)
+					mu.Unlock()
+				})
+			}()
+			waitFor(t, func() bool { return seen() == "old-1" })
+			tt.change(t, path)
+			waitFor(t, func() bool { return seen() == "old-1,new-a,new-b,new-c" })
+			cancel()
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,189 @@
+package logging
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+const (
+	hashPaletteSize   = 12
+	hashTrackedValues = 1024
+)
+
+type HashColorKey struct {
+	Key        string
+	Background bool
+	Bold       bool
+}
+
+type HashAssignment struct {
+	Key   string
+	Value string
+	Style style.Chalk
+}
+
+type hashKeyState struct {
+	cfg    HashColorKey
+	values map[string]int
+	used   map[int]int
+	order  []string
+}
+
+type HashColorProvider struct {
+	mu     sync.Mutex
+	fg     []style.Chalk
+	bg     []style.Chalk
+	keys   map[string]*hashKeyState
+	sorted []string
+}
+
+func NewHashColorProvider(theme style.Theme, keys ...string) *HashColorProvider {
+	p := &HashColorProvider{keys: map[string]*hashKeyState{}}
+	p.SetTheme(theme)
+	for _, key := range keys {
+		p.Configure(HashColorKey{Key: key})
+	}
+	return p
+}
+
+func (p *HashColorProvider) SetTheme(theme style.Theme) {
+	p.mu.Lock()
+	p.fg = style.DistinctColors(theme, hashPaletteSize)
+	p.bg = style.DistinctBadges(theme, hashPaletteSize)
+	p.mu.Unlock()
+}
+
+func (p *HashColorProvider) Configure(cfg HashColorKey) {
+	cfg.Key = strings.TrimSpace(cfg.Key)
+	if cfg.Key == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if st, ok := p.keys[cfg.Key]; ok {
+		st.cfg = cfg
+		return
+	}
+	p.keys[cfg.Key] = &hashKeyState{cfg: cfg, values: map[string]int{}, used: map[int]int{}}
+	p.sorted = append(p.sorted, cfg.Key)
+	sort.Strings(p.sorted)
+}
+
+func (p *HashColorProvider) Remove(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.keys, key)
+	for i, k := range p.sorted {
+		if k == key {
+			p.sorted = append(p.sorted[:i], p.sorted[i+1:]...)
+			break
+		}
+	}
+}
+
+func (p *HashColorProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, st := range p.keys {
+		st.values = map[string]int{}
+		st.used = map[int]int{}
+		st.order = nil
+	}
+}
+
+func (p *HashColorProvider) StyleField(key, value string) (style.Chalk, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.keys[key]
+	if !ok {
+		return style.Chalk{}, false
+	}
+	if value == "" {
+		return style.Chalk{}, false
+	}
+	return p.styleLocked(st, st.assignLocked(value)), true
+}
+
+func (st *hashKeyState) assignLocked(value string) int {
+	if idx, ok := st.values[value]; ok {
+		return idx
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+	idx := int(h.Sum32() % hashPaletteSize)
+	if len(st.values) >= hashTrackedValues {
+		return idx
+	}
+	if len(st.used) < hashPaletteSize {
+		for st.used[idx] > 0 {
+			idx = (idx + 1) % hashPaletteSize
+		}
+	}
+	st.values[value] = idx
+	st.used[idx]++
+	st.order = append(st.order, value)
+	return idx
+}
+
+func (p *HashColorProvider) styleLocked(st *hashKeyState, idx int) style.Chalk {
+	c := p.fg[idx]
+	if st.cfg.Background {
+		c = p.bg[idx]
+	}
+	if st.cfg.Bold {
+		c = c.Bold()
+	}
+	return c
+}
+
+func (p *HashColorProvider) Assignments(key string) []HashAssignment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []HashAssignment
+	for _, k := range p.sorted {
+		if key != "" && k != key {
+			continue
+		}
+		st := p.keys[k]
+		for _, v := range st.order {
+			out = append(out, HashAssignment{Key: k, Value: v, Style: p.styleLocked(st, st.values[v])})
+		}
+	}
+	return out
+}
+
+func (p *HashColorProvider) Keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.sorted...)
+}
+
+type MultiFieldProvider []FieldStyleProvider
+
+func (m MultiFieldProvider) StyleField(key, value string) (style.Chalk, bool) {
+	for _, p := range m {
+		if p == nil {
+			continue
+		}
+		if st, ok := p.StyleField(key, value); ok {
+			return st, true
+		}
+	}
+	return style.Chalk{}, false
+}
+
+func (m MultiFieldProvider) SetTheme(theme style.Theme) {
+	for _, p := range m {
+		if ta, ok := p.(themeAware); ok {
+			ta.SetTheme(theme)
+		}
+	}
+}
+
+type themeAware interface {
+	SetTheme(theme style.Theme)
+}
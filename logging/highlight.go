@@ -0,0 +1,197 @@
+package logging
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+type HighlightRule struct {
+	Pattern    string
+	Regex      bool
+	IgnoreCase bool
+	Style      style.Chalk
+	Priority   int
+}
+
+type compiledRule struct {
+	rule HighlightRule
+	re   *regexp.Regexp
+	seq  int
+}
+
+type HighlightSet struct {
+	mu    sync.RWMutex
+	rules []compiledRule
+	seq   int
+}
+
+var (
+	escapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+	highlights    = &HighlightSet{}
+)
+
+func Highlights() *HighlightSet {
+	return highlights
+}
+
+func NewHighlightSet(rules ...HighlightRule) (*HighlightSet, error) {
+	s := &HighlightSet{}
+	for _, rule := range rules {
+		if err := s.Add(rule); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *HighlightSet) Add(rule HighlightRule) error {
+	expr := rule.Pattern
+	if !rule.Regex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if rule.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	rules := make([]compiledRule, 0, len(s.rules)+1)
+	rules = append(rules, s.rules...)
+	rules = append(rules, compiledRule{rule: rule, re: re, seq: s.seq})
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].rule.Priority != rules[j].rule.Priority {
+			return rules[i].rule.Priority > rules[j].rule.Priority
+		}
+		return rules[i].seq < rules[j].seq
+	})
+	s.rules = rules
+	return nil
+}
+
+func (s *HighlightSet) Remove(pattern string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.rules {
+		if r.rule.Pattern == pattern {
+			rules := make([]compiledRule, 0, len(s.rules)-1)
+			rules = append(rules, s.rules[:i]...)
+			s.rules = append(rules, s.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *HighlightSet) Clear() {
+	s.mu.Lock()
+	s.rules = nil
+	s.mu.Unlock()
+}
+
+func (s *HighlightSet) Rules() []HighlightRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]HighlightRule, 0, len(s.rules))
+	for _, r := range s.rules {
+		out = append(out, r.rule)
+	}
+	return out
+}
+
+func (s *HighlightSet) Process(rec *LogRecord) {
+	s.mu.RLock()
+	empty := len(s.rules) == 0
+	s.mu.RUnlock()
+	if empty {
+		return
+	}
+	rec.Message = s.Apply(rec.Message)
+	for i := range rec.Fields {
+		f := &rec.Fields[i]
+		value := f.displayValue()
+		if out := s.Apply(value); out != value {
+			f.ValueOut = out
+		}
+	}
+}
+
+type highlightMatch struct {
+	start, end int
+	style      style.Chalk
+}
+
+func (s *HighlightSet) Apply(text string) string {
+	if text == "" {
+		return text
+	}
+	s.mu.RLock()
+	rules := s.rules
+	s.mu.RUnlock()
+	if len(rules) == 0 {
+		return text
+	}
+	var b strings.Builder
+	active := ""
+	last := 0
+	for _, loc := range escapePattern.FindAllStringIndex(text, -1) {
+		b.WriteString(highlightChunk(text[last:loc[0]], rules, active))
+		seq := text[loc[0]:loc[1]]
+		b.WriteString(seq)
+		if strings.HasSuffix(seq, "m") && strings.HasPrefix(seq, "\x1b[") {
+			if seq == "\x1b[0m" || seq == "\x1b[m" {
+				active = ""
+			} else {
+				active += seq
+			}
+		}
+		last = loc[1]
+	}
+	b.WriteString(highlightChunk(text[last:], rules, active))
+	return b.String()
+}
+
+func highlightChunk(chunk string, rules []compiledRule, active string) string {
+	if chunk == "" {
+		return chunk
+	}
+	var picked []highlightMatch
+	for _, r := range rules {
+		for _, loc := range r.re.FindAllStringIndex(chunk, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			overlaps := false
+			for _, m := range picked {
+				if loc[0] < m.end && m.start < loc[1] {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				picked = append(picked, highlightMatch{start: loc[0], end: loc[1], style: r.rule.Style})
+			}
+		}
+	}
+	if len(picked) == 0 {
+		return chunk
+	}
+	sort.Slice(picked, func(i, j int) bool { return picked[i].start < picked[j].start })
+	var b strings.Builder
+	last := 0
+	for _, m := range picked {
+		b.WriteString(chunk[last:m.start])
+		b.WriteString(m.style.Wrap(chunk[m.start:m.end]))
+		b.WriteString(active)
+		last = m.end
+	}
+	b.WriteString(chunk[last:])
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+func TestHighlightSetOrdersByPriority(t *testing.T) {
+	s, err := NewHighlightSet(
+		HighlightRule{Pattern: "a"},
+		HighlightRule{Pattern: "b", Priority: 2},
+		HighlightRule{Pattern: "c", Priority: 1},
+		HighlightRule{Pattern: "d", Priority: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := s.Rules()
+	if !s.Remove("b") || s.Remove("b") {
+		t.Fatal("Remove(b) should succeed exactly once")
+	}
+	got := ""
+	for _, r := range s.Rules() {
+		got += r.Pattern
+	}
+	if got != "dca" {
+		t.Fatalf("rules = %q, want %q", got, "dca")
+	}
+	if before[0].Pattern != "b" || len(before) != 4 {
+		t.Fatalf("earlier Rules() result changed: %v", before)
+	}
+}
+
+func TestHighlightSetConcurrentApply(t *testing.T) {
+	red := style.New().WithEnabled(true).Red()
+	s, err := NewHighlightSet(HighlightRule{Pattern: "joined", Style: red})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const text = "player joined world 7"
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; ; n++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				pattern := fmt.Sprintf("w%d-%d", i, n%8)
+				if err := s.Add(HighlightRule{Pattern: pattern, Style: red, Priority: n % 3}); err != nil {
+					t.Error(err)
+					return
+				}
+				s.Remove(pattern)
+			}
+		}()
+	}
+	want := s.Apply(text)
+	for range 2000 {
+		if got := s.Apply(text); style.StripANSI(got) != text || got != want {
+			t.Fatalf("Apply = %q, want %q", got, want)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type HumanizeKind int
+
+const (
+	HumanizeNone HumanizeKind = iota
+	HumanizeBytes
+	HumanizeDuration
+	HumanizeCount
+)
+
+type HumanizerConfig struct {
+	Keys   map[string]HumanizeKind
+	Detect bool
+}
+
+type humanizer struct {
+	keys   map[string]HumanizeKind
+	detect bool
+}
+
+func NewHumanizer(cfg HumanizerConfig) Processor {
+	keys := make(map[string]HumanizeKind, len(cfg.Keys))
+	for k, v := range cfg.Keys {
+		keys[k] = v
+	}
+	return humanizer{keys: keys, detect: cfg.Detect}
+}
+
+func (h humanizer) Process(rec *LogRecord) {
+	for i := range rec.Fields {
+		f := &rec.Fields[i]
+		if f.ValueOut != "" {
+			continue
+		}
+		kind, ok := h.keys[f.Key]
+		if !ok && h.detect {
+			kind = detectHumanizeKind(f.Key, f.Kind)
+		}
+		if out, ok := humanizeValue(kind, f.Value); ok {
+			f.ValueOut = out
+		}
+	}
+}
+
+func detectHumanizeKind(key string, kind ValueKind) HumanizeKind {
+	switch kind {
+	case KindDuration:
+		return HumanizeDuration
+	case KindNumber:
+		k := strings.ToLower(key)
+		if k == "bytes" || k == "size" || strings.HasSuffix(k, "_bytes") || strings.HasSuffix(k, "_size") {
+			return HumanizeBytes
+		}
+	}
+	return HumanizeNone
+}
+
+func humanizeValue(kind HumanizeKind, v string) (string, bool) {
+	switch kind {
+	case HumanizeBytes:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "", false
+		}
+		return HumanizeBytesValue(n), true
+	case HumanizeDuration:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return "", false
+		}
+		return HumanizeDurationValue(d), true
+	case HumanizeCount:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "", false
+		}
+		return HumanizeCountValue(n), true
+	}
+	return "", false
+}
+
+func HumanizeBytesValue(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	i := 0
+	for math.Abs(n) >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return strconv.FormatFloat(n, 'f', -1, 64) + " B"
+	}
+	return strconv.FormatFloat(n, 'f', 1, 64) + " " + units[i]
+}
+
+func HumanizeDurationValue(d time.Duration) string {
+	abs := d.Abs()
+	switch {
+	case abs >= time.Minute:
+		return d.Round(time.Second).String()
+	case abs >= time.Second:
+		return significant(d.Seconds()) + "s"
+	case abs >= time.Millisecond:
+		return significant(float64(d)/float64(time.Millisecond)) + "ms"
+	case abs >= time.Microsecond:
+		return significant(float64(d)/float64(time.Microsecond)) + "µs"
+	}
+	return d.String()
+}
+
+func HumanizeCountValue(n float64) string {
+	units := []string{"", "k", "M", "G", "T"}
+	i := 0
+	for math.Abs(n) >= 1000 && i < len(units)-1 {
+		n /= 1000
+		i++
+	}
+	if i == 0 {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return significant(n) + units[i]
+}
+
+func significant(v float64) string {
+	prec := 0
+	switch a := math.Abs(v); {
+	case a < 10:
+		prec = 2
+	case a < 100:
+		prec = 1
+	}
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
@@ -0,0 +1,329 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	indexVersion   = 1
+	indexSuffix    = ".idx"
+	bloomHashes    = 7
+	bloomFalseRate = 0.01
+	bloomMaxBytes  = 1 << 20
+	bloomMinBytes  = 8
+)
+
+type ArchiveIndex struct {
+	Version int            `json:"version"`
+	From    time.Time      `json:"from"`
+	To      time.Time      `json:"to"`
+	Lines   int            `json:"lines"`
+	Untimed int            `json:"untimed"`
+	Levels  map[string]int `json:"levels"`
+	BloomK  int            `json:"bloom_k"`
+	Bloom   []byte         `json:"bloom"`
+}
+
+type SearchStats struct {
+	Files   int
+	Skipped int
+	Lines   int
+	Matches int
+}
+
+type archiveIndexer struct {
+	idx   ArchiveIndex
+	bloom []byte
+	terms int
+	lines lineBuffer
+}
+
+func newArchiveIndexer() *archiveIndexer {
+	return &archiveIndexer{
+		idx:   ArchiveIndex{Version: indexVersion, Levels: map[string]int{}},
+		bloom: make([]byte, bloomMaxBytes),
+	}
+}
+
+func (x *archiveIndexer) addTerm(term string) {
+	if bloomAdd(x.bloom, bloomHashes, term) {
+		x.terms++
+	}
+}
+
+func (x *archiveIndexer) Write(p []byte) (int, error) {
+	return len(p), x.lines.write(p, x.add)
+}
+
+func (x *archiveIndexer) add(line string) error {
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	rec := ParseTextLogLine(line)
+	x.idx.Lines++
+	if t, ok := recordTime(rec); ok {
+		if x.idx.From.IsZero() || t.Before(x.idx.From) {
+			x.idx.From = t
+		}
+		if t.After(x.idx.To) {
+			x.idx.To = t
+		}
+	} else {
+		x.idx.Untimed++
+	}
+	level := slog.LevelInfo
+	if l, ok := ParseLevel(rec.Level); ok {
+		level = l
+	}
+	x.idx.Levels[LevelName(level)]++
+	for _, term := range recordTerms(rec) {
+		x.addTerm(term)
+	}
+	for _, f := range rec.Fields {
+		if f.Key == "" {
+			continue
+		}
+		x.addTerm(f.Key + "=")
+		x.addTerm(f.Key + "=" + f.Value)
+	}
+	return nil
+}
+
+func (x *archiveIndexer) finish() *ArchiveIndex {
+	_ = x.lines.flush(x.add)
+	bits := math.Ceil(-float64(max(x.terms, 1)) * math.Log(bloomFalseRate) / (math.Ln2 * math.Ln2))
+	size := len(x.bloom)
+	for size > bloomMinBytes && float64(size/2*8) >= bits {
+		size /= 2
+	}
+	x.idx.BloomK = bloomHashes
+	x.idx.Bloom = foldBloom(x.bloom, size)
+	return &x.idx
+}
+
+func foldBloom(bits []byte, size int) []byte {
+	out := make([]byte, size)
+	for i, b := range bits {
+		out[i%size] |= b
+	}
+	return out
+}
+
+func BuildArchiveIndex(path string) (*ArchiveIndex, error) {
+	rc, err := OpenLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rc io.ReadCloser) {
+		_ = rc.Close()
+	}(rc)
+	x := newArchiveIndexer()
+	if _, err := io.Copy(x, rc); err != nil {
+		return nil, err
+	}
+	return x.finish(), nil
+}
+
+func IndexArchive(path string) (*ArchiveIndex, error) {
+	idx, err := BuildArchiveIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	return idx, writeArchiveIndex(path, idx)
+}
+
+func IndexArchives(paths []string) (int, error) {
+	built := 0
+	var errs []error
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".gz") {
+			continue
+		}
+		if _, err := os.Stat(path + indexSuffix); err == nil {
+			continue
+		}
+		if _, err := IndexArchive(path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		built++
+	}
+	return built, errors.Join(errs...)
+}
+
+func LoadArchiveIndex(path string) (*ArchiveIndex, error) {
+	data, err := os.ReadFile(path + indexSuffix)
+	if err != nil {
+		return nil, err
+	}
+	idx := &ArchiveIndex{}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, err
+	}
+	if idx.Version != indexVersion {
+		return nil, errors.New("unsupported index version")
+	}
+	return idx, nil
+}
+
+func writeArchiveIndex(path string, idx *ArchiveIndex) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	tmp := path + indexSuffix + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path+indexSuffix)
+}
+
+func (idx *ArchiveIndex) MayContain(term string) bool {
+	return len(idx.Bloom) == 0 || bloomHas(idx.Bloom, idx.BloomK, term)
+}
+
+func (q RecordQuery) MayMatchIndex(idx *ArchiveIndex) bool {
+	if idx == nil {
+		return true
+	}
+	if !q.Since.IsZero() && (idx.To.IsZero() || idx.To.Before(q.Since)) {
+		return false
+	}
+	if !q.Until.IsZero() && idx.Untimed == 0 && idx.From.After(q.Until) {
+		return false
+	}
+	if q.MinLevel != nil {
+		found := false
+		for name, n := range idx.Levels {
+			l, ok := ParseLevel(name)
+			if n > 0 && (!ok || l >= q.MinLevel.Level()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for key, want := range q.Fields {
+		if !idx.MayContain(key + "=" + want) {
+			return false
+		}
+	}
+	for _, term := range q.Terms {
+		for _, tok := range tokenizeTerms(term) {
+			if !idx.MayContain(tok) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func PruneLogFiles(paths []string, q RecordQuery) (keep []string, skipped int) {
+	keep = make([]string, 0, len(paths))
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".gz") {
+			if idx, err := LoadArchiveIndex(path); err == nil && !q.MayMatchIndex(idx) {
+				skipped++
+				continue
+			}
+		}
+		keep = append(keep, path)
+	}
+	return keep, skipped
+}
+
+func SearchLogFiles(ctx context.Context, paths []string, q RecordQuery, parser Parser, fn func(LogLine) bool) (SearchStats, error) {
+	keep, skipped := PruneLogFiles(paths, q)
+	stats := SearchStats{Files: len(keep), Skipped: skipped}
+	err := MergeLogFiles(keep, parser, func(line LogLine) bool {
+		stats.Lines++
+		if q.MatchRecord(line.Record) {
+			stats.Matches++
+			if !fn(line) {
+				return false
+			}
+		}
+		return ctx.Err() == nil
+	})
+	if err == nil {
+		err = ctx.Err()
+	}
+	return stats, err
+}
+
+func matchTerms(rec *LogRecord, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	have := map[string]struct{}{}
+	for _, term := range recordTerms(rec) {
+		have[term] = struct{}{}
+	}
+	for _, term := range terms {
+		for _, tok := range tokenizeTerms(term) {
+			if _, ok := have[tok]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func recordTerms(rec *LogRecord) []string {
+	out := tokenizeTerms(rec.Message)
+	for _, f := range rec.Fields {
+		out = append(out, tokenizeTerms(f.Value)...)
+	}
+	return out
+}
+
+func tokenizeTerms(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
+func bloomAdd(bits []byte, k int, term string) bool {
+	h1, h2 := bloomHash(term)
+	m := uint64(len(bits) * 8)
+	added := false
+	for i := 0; i < k; i++ {
+		pos := (h1 + uint64(i)*h2) % m
+		if bits[pos/8]&(1<<(pos%8)) == 0 {
+			bits[pos/8] |= 1 << (pos % 8)
+			added = true
+		}
+	}
+	return added
+}
+
+func bloomHas(bits []byte, k int, term string) bool {
+	h1, h2 := bloomHash(term)
+	m := uint64(len(bits) * 8)
+	for i := 0; i < k; i++ {
+		pos := (h1 + uint64(i)*h2) % m
+		if bits[pos/8]&(1<<(pos%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func bloomHash(term string) (uint64, uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(term))
+	sum := h.Sum64()
+	return sum, sum>>33 | sum<<31 | 1
+}
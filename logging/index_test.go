@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func buildTestIndex(lines ...string) *ArchiveIndex {
+	x := newArchiveIndexer()
+	for _, line := range lines {
+		_, _ = x.Write([]byte(line + "\n"))
+	}
+	return x.finish()
+}
+
+func TestMayMatchIndex(t *testing.T) {
+	idx := buildTestIndex(
+		"time=2026-01-02T10:00:00.000Z level=INFO msg=\"player joined\" player=hub_snow",
+		"time=2026-01-02T11:00:00.000Z level=WARN msg=\"slow tick\" took=120ms",
+		"time=2026-01-02T12:00:00.000Z level=INFO msg=\"player left\" player=hub_snow",
+	)
+	at := func(h int) time.Time { return time.Date(2026, 1, 2, h, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name string
+		q    RecordQuery
+		want bool
+	}{
+		{"empty query", RecordQuery{}, true},
+		{"since inside", RecordQuery{Since: at(11)}, true},
+		{"since after", RecordQuery{Since: at(13)}, false},
+		{"until inside", RecordQuery{Until: at(10)}, true},
+		{"until before", RecordQuery{Until: at(9)}, false},
+		{"level present", RecordQuery{MinLevel: slog.LevelWarn}, true},
+		{"level absent", RecordQuery{MinLevel: slog.LevelError}, false},
+		{"field match", RecordQuery{Fields: map[string]string{"player": "hub_snow"}}, true},
+		{"field other value", RecordQuery{Fields: map[string]string{"player": "someone_else"}}, false},
+		{"field key only", RecordQuery{Fields: map[string]string{"took": ""}}, true},
+		{"field key absent", RecordQuery{Fields: map[string]string{"err": ""}}, false},
+		{"term in message", RecordQuery{Terms: []string{"Joined"}}, true},
+		{"term in value", RecordQuery{Terms: []string{"hub_snow"}}, true},
+		{"all words of term", RecordQuery{Terms: []string{"slow tick"}}, true},
+		{"missing word", RecordQuery{Terms: []string{"slow crash"}}, false},
+		{"nil index", RecordQuery{Since: at(20)}, true},
+	}
+	for _, tt := range tests {
+		in := idx
+		if tt.name == "nil index" {
+			in = nil
+		}
+		if got := tt.q.MayMatchIndex(in); got != tt.want {
+			t.Errorf("%s: MayMatchIndex = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestArchiveIndexBloomSize(t *testing.T) {
+	small := buildTestIndex("level=INFO msg=hello")
+	if len(small.Bloom) > 64 {
+		t.Errorf("bloom for one line is %d bytes", len(small.Bloom))
+	}
+	lines := make([]string, 20000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("level=INFO msg=\"tick %d\" id=u%d", i, i)
+	}
+	big := buildTestIndex(lines...)
+	if len(big.Bloom) >= bloomMaxBytes || len(big.Bloom) < 40000*10/8 {
+		t.Errorf("bloom for %d lines is %d bytes", len(lines), len(big.Bloom))
+	}
+	for i := 0; i < len(lines); i += 997 {
+		if !big.MayContain(fmt.Sprintf("u%d", i)) || !big.MayContain(fmt.Sprintf("id=u%d", i)) {
+			t.Fatalf("folded bloom lost u%d", i)
+		}
+	}
+	misses := 0
+	for i := 0; i < 10000; i++ {
+		if big.MayContain(fmt.Sprintf("absent%d", i)) {
+			misses++
+		}
+	}
+	if misses > 300 {
+		t.Errorf("false positive rate %d/10000", misses)
+	}
+}
@@ -0,0 +1,177 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	LevelTrace  = slog.Level(-8)
+	LevelDebug  = slog.LevelDebug
+	LevelInfo   = slog.LevelInfo
+	LevelNotice = slog.Level(2)
+	LevelWarn   = slog.LevelWarn
+	LevelError  = slog.LevelError
+	LevelFatal  = slog.Level(12)
+)
+
+type LevelSpec struct {
+	Name  string
+	Value slog.Level
+	Label string
+	Icon  string
+}
+
+var (
+	levelsMu sync.RWMutex
+	levels   = map[string]LevelSpec{}
+	levelVar = new(slog.LevelVar)
+)
+
+func init() {
+	for _, spec := range []LevelSpec{
+		{Name: "TRACE", Value: LevelTrace, Label: "TRC", Icon: "·"},
+		{Name: "DEBUG", Value: LevelDebug, Label: "DBG", Icon: "•"},
+		{Name: "INFO", Value: LevelInfo, Label: "INF", Icon: "ℹ"},
+		{Name: "NOTICE", Value: LevelNotice, Label: "NTC", Icon: "◆"},
+		{Name: "WARN", Value: LevelWarn, Label: "WRN", Icon: "⚠"},
+		{Name: "ERROR", Value: LevelError, Label: "ERR", Icon: "✖"},
+		{Name: "FATAL", Value: LevelFatal, Label: "FTL", Icon: "☠"},
+	} {
+		_ = RegisterLevel(spec)
+	}
+}
+
+func RegisterLevel(spec LevelSpec) error {
+	spec.Name = strings.ToUpper(strings.TrimSpace(spec.Name))
+	if spec.Name == "" {
+		return fmt.Errorf("level name is empty")
+	}
+	if spec.Label == "" {
+		spec.Label = spec.Name
+	}
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	for name, other := range levels {
+		if other.Value == spec.Value && name != spec.Name {
+			return fmt.Errorf("level %s: value %d is already registered as %s", spec.Name, spec.Value, name)
+		}
+	}
+	levels[spec.Name] = spec
+	return nil
+}
+
+func Levels() []LevelSpec {
+	levelsMu.RLock()
+	out := make([]LevelSpec, 0, len(levels))
+	for _, spec := range levels {
+		out = append(out, spec)
+	}
+	levelsMu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Value < out[j].Value })
+	return out
+}
+
+func LookupLevel(name string) (LevelSpec, bool) {
+	l, ok := ParseLevel(name)
+	if !ok {
+		return LevelSpec{}, false
+	}
+	return levelSpecFor(l)
+}
+
+func ParseLevel(s string) (slog.Level, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, false
+	}
+	levelsMu.RLock()
+	spec, ok := levels[s]
+	levelsMu.RUnlock()
+	if ok {
+		return spec.Value, true
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return slog.Level(n), true
+	}
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	found, best := false, ""
+	var value slog.Level
+	for name, spec := range levels {
+		rest, ok := strings.CutPrefix(s, name)
+		if !ok || len(rest) < 2 || (rest[0] != '+' && rest[0] != '-') || len(name) <= len(best) {
+			continue
+		}
+		n, err := strconv.Atoi(rest)
+		if err != nil {
+			continue
+		}
+		found, best, value = true, name, spec.Value+slog.Level(n)
+	}
+	return value, found
+}
+
+func LevelName(l slog.Level) string {
+	if spec, ok := levelSpecFor(l); ok {
+		return spec.Name
+	}
+	return l.String()
+}
+
+func levelSpecFor(l slog.Level) (LevelSpec, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	for _, spec := range levels {
+		if spec.Value == l {
+			return spec, true
+		}
+	}
+	return LevelSpec{}, false
+}
+
+func canonicalLevel(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if l, ok := ParseLevel(name); ok {
+		return LevelName(l)
+	}
+	return name
+}
+
+func levelToken(name string) string {
+	l, ok := ParseLevel(name)
+	if !ok {
+		return "level.info"
+	}
+	switch {
+	case l < LevelInfo:
+		return "level.debug"
+	case l < LevelWarn:
+		return "level.info"
+	case l < LevelError:
+		return "level.warn"
+	}
+	return "level.error"
+}
+
+func SetLevel(l slog.Level) {
+	levelVar.Set(l)
+}
+
+func CurrentLevel() slog.Level {
+	return levelVar.Level()
+}
+
+func replaceLevelAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 || a.Key != slog.LevelKey {
+		return a
+	}
+	if l, ok := a.Value.Any().(slog.Level); ok {
+		return slog.String(slog.LevelKey, LevelName(l))
+	}
+	return a
+}
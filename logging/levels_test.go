@@ -0,0 +1,118 @@
+package logging
+
+import (
+	"log/slog"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+func restoreLevels(t *testing.T) {
+	t.Helper()
+	levelsMu.RLock()
+	saved := maps.Clone(levels)
+	levelsMu.RUnlock()
+	t.Cleanup(func() {
+		levelsMu.Lock()
+		levels = saved
+		levelsMu.Unlock()
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	restoreLevels(t)
+	if err := RegisterLevel(LevelSpec{Name: "audit-log", Value: 6, Label: "AUD"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in   string
+		want slog.Level
+		ok   bool
+	}{
+		{"info", LevelInfo, true},
+		{" WARN ", LevelWarn, true},
+		{"trace", LevelTrace, true},
+		{"-3", -3, true},
+		{"DEBUG-4", LevelDebug - 4, true},
+		{"ERROR+4", LevelError + 4, true},
+		{"audit-log", 6, true},
+		{"AUDIT-LOG+2", 8, true},
+		{"audit-log-1", 5, true},
+		{"audit", 0, false},
+		{"WARN+", 0, false},
+		{"WARN+x", 0, false},
+		{"", 0, false},
+		{"nope", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLevel(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRegisterLevelRejectsDuplicateValue(t *testing.T) {
+	restoreLevels(t)
+	if err := RegisterLevel(LevelSpec{Name: "CAUTION", Value: LevelWarn}); err == nil {
+		t.Fatal("registering a second name for WARN succeeded")
+	}
+	if got := LevelName(LevelWarn); got != "WARN" {
+		t.Fatalf("LevelName(WARN) = %q", got)
+	}
+	if err := RegisterLevel(LevelSpec{Name: "warn", Value: LevelWarn, Label: "WRN", Icon: "⚠"}); err != nil {
+		t.Fatalf("re-registering WARN: %v", err)
+	}
+}
+
+func TestTextLevelAliases(t *testing.T) {
+	tests := []struct {
+		line  string
+		level string
+		token string
+	}{
+		{"level=WARNING msg=a", "WARN", "level.warn"},
+		{"level=err msg=a", "ERROR", "level.error"},
+		{"level=INFO+2 msg=a", "NOTICE", "level.info"},
+		{"level=ERROR+4 msg=a", "FATAL", "level.error"},
+		{"level=weird msg=a", "WEIRD", "level.info"},
+	}
+	for _, tt := range tests {
+		rec := ParseTextLogLine(tt.line)
+		if rec.Level != tt.level {
+			t.Errorf("%q: level = %q, want %q", tt.line, rec.Level, tt.level)
+		}
+		if got := levelToken(rec.Level); got != tt.token {
+			t.Errorf("%q: token = %q, want %q", tt.line, got, tt.token)
+		}
+		if got := renderTextRecord(rec); !strings.HasPrefix(got, "level="+tt.line[len("level="):strings.IndexByte(tt.line, ' ')]) {
+			t.Errorf("%q: unchanged level rewritten as %q", tt.line, got)
+		}
+	}
+}
+
+func TestAlignedLevelWidthFollowsRegistry(t *testing.T) {
+	r := newDefaultRenderer(style.DefaultTheme(), Profile{Aligned: true}).(defaultRenderer)
+	restoreLevels(t)
+	before := r.levelWidth()
+	if err := RegisterLevel(LevelSpec{Name: "SECURITY", Value: 11, Label: "SECURITY"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.levelWidth(); got <= before || got != len("SECURITY") {
+		t.Fatalf("levelWidth = %d after registering SECURITY (was %d)", got, before)
+	}
+}
+
+func TestRestoreLevelsDropsTestRegistrations(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		restoreLevels(t)
+		if err := RegisterLevel(LevelSpec{Name: "scratch", Value: 42}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if _, ok := ParseLevel("scratch"); ok {
+		t.Fatal("level registered in a subtest leaked into the registry")
+	}
+}
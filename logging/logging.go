@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,9 +23,13 @@ type RecordField struct {
 	Key      string
 	Value    string
 	ValueOut string
+	Kind     ValueKind
+	Quoted   bool
 	ShowKey  bool
 	Styled   bool
 	Style    style.Chalk
+
+	src *textToken
 }
 
 type LogRecord struct {
@@ -33,12 +38,30 @@ type LogRecord struct {
 	Level   string
 	Message string
 	Fields  []RecordField
+
+	head []headToken
+	lead string
+	tail string
 }
 
 type Profile struct {
 	HideKeys    map[string]bool
 	LevelLabels map[string]string
 	CompactMode bool
+
+	Aligned      bool
+	MessageWidth int
+	WrapLines    bool
+
+	TimeLayout   string
+	TimeLocation *time.Location
+	TimeMode     TimeMode
+
+	FieldOrder  []string
+	FieldLast   []string
+	MaxFields   int
+	MaxValueLen map[string]int
+	DropEmpty   bool
 }
 
 func DefaultProfile() Profile {
@@ -73,6 +96,9 @@ func normalizeProfile(p Profile) Profile {
 	if !p.CompactMode {
 		p.CompactMode = d.CompactMode
 	}
+	if p.MessageWidth <= 0 {
+		p.MessageWidth = defaultMessageWidth
+	}
 	return p
 }
 
@@ -96,6 +122,10 @@ func (f RendererFunc) Render(rec *LogRecord) string {
 	return f(rec)
 }
 
+type forkableRenderer interface {
+	fork() Renderer
+}
+
 type FieldStyleProvider interface {
 	StyleField(key, value string) (style.Chalk, bool)
 }
@@ -158,23 +188,32 @@ func (p fieldStyleProcessor) Process(rec *LogRecord) {
 	}
 }
 
-type errorFieldProcessor struct {
-	errStyle style.Chalk
+type themeFieldProcessor struct {
+	theme style.Theme
 }
 
-func (p errorFieldProcessor) Process(rec *LogRecord) {
+func (p themeFieldProcessor) Process(rec *LogRecord) {
 	for i := range rec.Fields {
 		if rec.Fields[i].Styled {
 			continue
 		}
-		if rec.Fields[i].Key == "err" || rec.Fields[i].Key == "error" {
-			rec.Fields[i].Style = p.errStyle
+		key := rec.Fields[i].Key
+		if key == "" {
+			continue
+		}
+		st, ok := p.theme.Override("attr." + key)
+		if !ok && (key == "err" || key == "error") {
+			st, ok = p.theme.Lookup("attr." + key)
+		}
+		if ok {
+			rec.Fields[i].Style = st
 			rec.Fields[i].Styled = true
 		}
 	}
 }
 
 type Pipeline struct {
+	parser     Parser
 	processors []Processor
 	renderer   Renderer
 }
@@ -183,7 +222,7 @@ func NewPipeline(theme style.Theme, profile Profile, provider FieldStyleProvider
 	processors := []Processor{
 		fieldTransformProcessor{transformer: transformer},
 		fieldStyleProcessor{provider: provider},
-		errorFieldProcessor{errStyle: theme.ErrKey},
+		themeFieldProcessor{theme: theme},
 	}
 	processors = append(processors, extras...)
 	if renderer == nil {
@@ -192,150 +231,42 @@ func NewPipeline(theme style.Theme, profile Profile, provider FieldStyleProvider
 	return &Pipeline{processors: processors, renderer: renderer}
 }
 
-func (p *Pipeline) Colorize(line string) string {
-	rec := ParseTextLogLine(line)
-	for _, proc := range p.processors {
-		proc.Process(rec)
-	}
-	return p.renderer.Render(rec)
+func (p *Pipeline) WithParser(parser Parser) *Pipeline {
+	out := *p
+	out.parser = parser
+	return &out
 }
 
-type defaultRenderer struct {
-	theme   style.Theme
-	profile Profile
-}
-
-func newDefaultRenderer(theme style.Theme, profile Profile) Renderer {
-	return defaultRenderer{theme: theme, profile: normalizeProfile(profile)}
-}
-
-func (r defaultRenderer) Render(rec *LogRecord) string {
-	parts := make([]string, 0, 3+len(rec.Fields))
-	if rec.Time != "" {
-		parts = append(parts, r.theme.TimeValue.Dim().Wrap(rec.Time))
-	}
-	if rec.Level != "" {
-		parts = append(parts, r.levelBadge(rec.Level))
-	}
-	if rec.Message != "" {
-		parts = append(parts, rec.Message)
+func (p *Pipeline) Fork() *Pipeline {
+	out := *p
+	if f, ok := p.renderer.(forkableRenderer); ok {
+		out.renderer = f.fork()
 	}
-	for _, f := range rec.Fields {
-		value := f.Value
-		if f.ValueOut != "" {
-			value = f.ValueOut
-		}
-		if f.Styled {
-			value = f.Style.Wrap(value)
-		}
-		showKey := f.ShowKey
-		if r.profile.CompactMode {
-			if hidden, ok := r.profile.HideKeys[f.Key]; ok && hidden {
-				showKey = false
-			}
-		}
-		if !showKey {
-			parts = append(parts, value)
-			continue
-		}
-		parts = append(parts, f.Key+"="+value)
-	}
-	return strings.Join(parts, " ")
+	return &out
 }
 
-func (r defaultRenderer) levelBadge(level string) string {
-	lvl := strings.ToUpper(level)
-	label := lvl
-	if l, ok := r.profile.LevelLabels[lvl]; ok {
-		label = l
-	}
-	switch lvl {
-	case "DEBUG":
-		return r.theme.Debug.Wrap(label)
-	case "WARN":
-		return r.theme.Warn.Wrap(label)
-	case "ERROR":
-		return r.theme.Error.Wrap(label)
-	default:
-		return r.theme.Info.Wrap(label)
-	}
+func (p *Pipeline) Parse(line string) *LogRecord {
+	return parseWith(p.parser, line)
 }
 
-func ParseTextLogLine(line string) *LogRecord {
-	rec := &LogRecord{Raw: line, Fields: make([]RecordField, 0, 16)}
-	tokens := splitQuotedTokens(line)
-	for _, tok := range tokens {
-		i := strings.IndexByte(tok, '=')
-		if i <= 0 {
-			continue
-		}
-		key := tok[:i]
-		value := tok[i+1:]
-		switch key {
-		case "time":
-			rec.Time = value
-		case "level":
-			rec.Level = strings.Trim(value, "\"")
-		case "msg":
-			rec.Message = value
-		default:
-			rec.Fields = append(rec.Fields, RecordField{
-				Key:     key,
-				Value:   value,
-				ShowKey: true,
-			})
-		}
-	}
-	return rec
+func (p *Pipeline) Colorize(line string) string {
+	return p.Render(p.Parse(line))
 }
 
-func splitQuotedTokens(s string) []string {
-	tokens := make([]string, 0, 16)
-	start := -1
-	inQuotes := false
-	escaped := false
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if start == -1 && c != ' ' {
-			start = i
-		}
-		if start == -1 {
-			continue
-		}
-		if inQuotes {
-			if escaped {
-				escaped = false
-				continue
-			}
-			if c == '\\' {
-				escaped = true
-				continue
-			}
-			if c == '"' {
-				inQuotes = false
-			}
-			continue
-		}
-		if c == '"' {
-			inQuotes = true
-			continue
-		}
-		if c == ' ' {
-			tokens = append(tokens, s[start:i])
-			start = -1
-		}
-	}
-	if start != -1 {
-		tokens = append(tokens, s[start:])
+func (p *Pipeline) Render(rec *LogRecord) string {
+	for _, proc := range p.processors {
+		proc.Process(rec)
 	}
-	return tokens
+	return p.renderer.Render(rec)
 }
 
 var (
 	stateMu      sync.RWMutex
 	currentTheme = style.DefaultTheme()
 	currentProf  = DefaultProfile()
+	currentCfg   LoggerConfig
 	pipeline     = NewPipeline(currentTheme, currentProf, nil, nil, nil, nil)
+	aggregators  []*AggregateLineWriter
 )
 
 type LoggerConfig struct {
@@ -347,8 +278,20 @@ type LoggerConfig struct {
 	FieldProvider  FieldStyleProvider
 	FieldTransform FieldTransformer
 	Processors     []Processor
+	FileProcessors []Processor
+	Parser         Parser
 	Renderer       Renderer
+	Format         string
 	Dedupe         DedupeConfig
+	Highlights     []HighlightRule
+
+	HighlightValues  bool
+	DetectBackground bool
+
+	StdLogLevel   slog.Leveler
+	CaptureStdio  bool
+	CapturePanics bool
+	RingSize      int
 }
 
 type DedupeConfig struct {
@@ -370,12 +313,25 @@ func SetupDefaultSlog(cfg LoggerConfig) (*os.File, error) {
 	if theme.TimeKey.Wrap("x") == "x" {
 		theme = style.DefaultTheme()
 	}
+	if cfg.DetectBackground {
+		theme = themeForBackground(theme, term.DetectBackground(term.DefaultQueryTimeout))
+	}
 	prof := normalizeProfile(cfg.Profile)
-	pl := NewPipeline(theme, prof, cfg.FieldProvider, cfg.FieldTransform, cfg.Processors, cfg.Renderer)
+	highlights.Clear()
+	for _, rule := range cfg.Highlights {
+		if err := highlights.Add(rule); err != nil {
+			return nil, fmt.Errorf("highlight %q: %w", rule.Pattern, err)
+		}
+	}
+	pl, err := buildPipeline(cfg, theme, prof)
+	if err != nil {
+		return nil, err
+	}
 
 	stateMu.Lock()
 	currentTheme = theme
 	currentProf = prof
+	currentCfg = cfg
 	pipeline = pl
 	stateMu.Unlock()
 
@@ -395,23 +351,128 @@ func SetupDefaultSlog(cfg LoggerConfig) (*os.File, error) {
 		return nil, fmt.Errorf("open %s: %w", logPath, err)
 	}
 
-	consoleSink := io.Writer(NewColorizingWriter(os.Stdout))
-	fileSink := io.Writer(file)
+	consoleSink := io.Writer(NewColorizingWriter(sinkWriter{sink: "console", dst: term.ConsoleOut()}))
+	fileSink := io.Writer(sinkWriter{sink: "file", dst: file})
+	if len(cfg.FileProcessors) > 0 {
+		fileSink = NewProcessingWriter(fileSink, cfg.FileProcessors...)
+	}
+	var aggs []*AggregateLineWriter
 	if cfg.Dedupe.Enabled {
 		window := cfg.Dedupe.Window
 		if window <= 0 {
 			window = time.Second
 		}
-		consoleSink = NewAggregateLineWriter(consoleSink, window, cfg.Dedupe.KeyFunc, cfg.Dedupe.Remap)
-		fileSink = NewAggregateLineWriter(fileSink, window, cfg.Dedupe.KeyFunc, cfg.Dedupe.Remap)
+		consoleAgg := NewAggregateLineWriter(consoleSink, window, cfg.Dedupe.KeyFunc, cfg.Dedupe.Remap)
+		fileAgg := NewAggregateLineWriter(fileSink, window, cfg.Dedupe.KeyFunc, cfg.Dedupe.Remap)
+		consoleAgg.sink, fileAgg.sink = "console", "file"
+		consoleSink, fileSink = consoleAgg, fileAgg
+		aggs = []*AggregateLineWriter{consoleAgg, fileAgg}
+	}
+	flushSinks()
+	stateMu.Lock()
+	aggregators = aggs
+	stateMu.Unlock()
+
+	if cfg.CapturePanics {
+		if err := debug.SetCrashOutput(file, debug.CrashOptions{}); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("crash output: %w", err)
+		}
 	}
 
-	consoleHandler := slog.NewTextHandler(consoleSink, &slog.HandlerOptions{Level: cfg.Level})
-	fileHandler := slog.NewTextHandler(fileSink, &slog.HandlerOptions{Level: cfg.Level})
-	slog.SetDefault(slog.New(fanoutHandler{handlers: []slog.Handler{consoleHandler, fileHandler}}))
+	levelVar.Set(cfg.Level)
+	opts := &slog.HandlerOptions{Level: levelVar, ReplaceAttr: replaceLevelAttr}
+	consoleHandler := slog.NewTextHandler(consoleSink, opts)
+	fileHandler := slog.NewTextHandler(fileSink, opts)
+	recent.Resize(cfg.RingSize)
+	ringHandler := slog.NewTextHandler(recent, opts)
+	prev := slog.Default()
+	slog.SetDefault(slog.New(fanoutHandler{handlers: []slog.Handler{consoleHandler, fileHandler, ringHandler}}))
+	if cfg.StdLogLevel != nil {
+		slog.SetLogLoggerLevel(cfg.StdLogLevel.Level())
+	}
+	if cfg.CaptureStdio {
+		if err := CaptureStdio(CaptureConfig{}); err != nil {
+			slog.SetDefault(prev)
+			_ = file.Close()
+			return nil, fmt.Errorf("capture stdio: %w", err)
+		}
+	}
 	return file, nil
 }
 
+func themeForBackground(theme style.Theme, bg term.Background) style.Theme {
+	switch bg {
+	case term.BackgroundLight:
+		return theme.ForVariant(style.VariantLight)
+	case term.BackgroundDark:
+		return theme.ForVariant(style.VariantDark)
+	default:
+		return theme
+	}
+}
+
+func SetTheme(theme style.Theme) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	currentTheme = theme
+	if pl, err := buildPipeline(currentCfg, theme, currentProf); err == nil {
+		pipeline = pl
+	}
+}
+
+func previewTheme(theme style.Theme, lines []string) ([]string, error) {
+	stateMu.RLock()
+	cfg, prof, cur := currentCfg, currentProf, currentTheme
+	stateMu.RUnlock()
+	pl, err := buildPipeline(cfg, theme, prof)
+	if ta, ok := cfg.FieldProvider.(themeAware); ok {
+		defer ta.SetTheme(cur)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, pl.Colorize(line))
+	}
+	return out, nil
+}
+
+func buildPipeline(cfg LoggerConfig, theme style.Theme, prof Profile) (*Pipeline, error) {
+	if ta, ok := cfg.FieldProvider.(themeAware); ok {
+		ta.SetTheme(theme)
+	}
+	renderer := cfg.Renderer
+	if renderer == nil && strings.TrimSpace(cfg.Format) != "" {
+		tr, err := NewTemplateRenderer(cfg.Format, theme, prof)
+		if err != nil {
+			return nil, err
+		}
+		renderer = tr
+	}
+	extras := make([]Processor, 0, len(cfg.Processors)+2)
+	if cfg.HighlightValues {
+		extras = append(extras, NewValueHighlighter(theme))
+	}
+	extras = append(extras, cfg.Processors...)
+	extras = append(extras, highlights)
+	pl := NewPipeline(theme, prof, cfg.FieldProvider, cfg.FieldTransform, extras, renderer)
+	return pl.WithParser(cfg.Parser), nil
+}
+
+func CurrentTheme() style.Theme {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return currentTheme
+}
+
+func CurrentProfile() Profile {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return currentProf
+}
+
 func RotateAndCompressLog(srcPath, archiveDir string) error {
 	srcPath = strings.TrimSpace(srcPath)
 	if srcPath == "" {
@@ -450,8 +511,9 @@ func RotateAndCompressLog(srcPath, archiveDir string) error {
 		return err
 	}
 	gz := gzip.NewWriter(out)
+	indexer := newArchiveIndexer()
 
-	_, copyErr := io.Copy(gz, in)
+	_, copyErr := io.Copy(gz, io.TeeReader(in, indexer))
 	closeErr := gz.Close()
 	outCloseErr := out.Close()
 	if copyErr != nil {
@@ -463,11 +525,16 @@ func RotateAndCompressLog(srcPath, archiveDir string) error {
 	if outCloseErr != nil {
 		return outCloseErr
 	}
+	if err := writeArchiveIndex(dst, indexer.finish()); err != nil {
+		slog.Warn("archive index not written; the archive will be scanned in full", "archive", dst, "err", err)
+	}
 	return os.Truncate(srcPath, 0)
 }
 
 type fanoutHandler struct {
-	handlers []slog.Handler
+	handlers  []slog.Handler
+	component string
+	grouped   bool
 }
 
 func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
@@ -480,6 +547,15 @@ func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
 }
 
 func (h fanoutHandler) Handle(ctx context.Context, rec slog.Record) error {
+	component := h.component
+	rec.Attrs(func(a slog.Attr) bool {
+		if !h.grouped && a.Key == "component" {
+			component = a.Value.String()
+			return false
+		}
+		return true
+	})
+	metrics.countRecord(rec.Level, component, rec.Message)
 	var firstErr error
 	for _, next := range h.handlers {
 		if err := next.Handle(ctx, rec); err != nil && firstErr == nil {
@@ -494,50 +570,148 @@ func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	for _, next := range h.handlers {
 		out = append(out, next.WithAttrs(attrs))
 	}
-	return fanoutHandler{handlers: out}
+	component := h.component
+	for _, a := range attrs {
+		if !h.grouped && a.Key == "component" {
+			component = a.Value.String()
+		}
+	}
+	return fanoutHandler{handlers: out, component: component, grouped: h.grouped}
 }
 
 func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
 	out := make([]slog.Handler, 0, len(h.handlers))
 	for _, next := range h.handlers {
 		out = append(out, next.WithGroup(name))
 	}
-	return fanoutHandler{handlers: out}
+	return fanoutHandler{handlers: out, component: h.component, grouped: true}
 }
 
-type ColorizingWriter struct {
-	dst io.Writer
+type lineBuffer struct {
 	buf []byte
 }
 
+func (b *lineBuffer) write(p []byte, fn func(line string) error) error {
+	b.buf = append(b.buf, p...)
+	for {
+		i := bytes.IndexByte(b.buf, '\n')
+		if i < 0 {
+			return nil
+		}
+		line := strings.TrimSuffix(string(b.buf[:i]), "\r")
+		b.buf = b.buf[i+1:]
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *lineBuffer) flush(fn func(line string) error) error {
+	if len(b.buf) == 0 {
+		return nil
+	}
+	line := strings.TrimSuffix(string(b.buf), "\r")
+	b.buf = b.buf[:0]
+	return fn(line)
+}
+
+type ColorizingWriter struct {
+	dst    io.Writer
+	parser Parser
+	lines  lineBuffer
+	base   *Pipeline
+	own    *Pipeline
+}
+
 func NewColorizingWriter(dst io.Writer) *ColorizingWriter {
 	return &ColorizingWriter{dst: dst}
 }
 
 func (w *ColorizingWriter) Write(p []byte) (int, error) {
-	w.buf = append(w.buf, p...)
-	for {
-		i := bytes.IndexByte(w.buf, '\n')
-		if i < 0 {
-			break
-		}
-		line := string(w.buf[:i])
-		w.buf = w.buf[i+1:]
-		if _, err := io.WriteString(w.dst, ColorizeLogLine(line)+"\n"); err != nil {
-			return len(p), err
-		}
+	return len(p), w.lines.write(p, w.writeLine)
+}
+
+func (w *ColorizingWriter) Flush() error {
+	return w.lines.flush(w.writeLine)
+}
+
+func (w *ColorizingWriter) writeLine(line string) error {
+	_, err := io.WriteString(w.dst, w.colorize(line)+"\n")
+	return err
+}
+
+type ProcessingWriter struct {
+	dst        io.Writer
+	processors []Processor
+	mu         sync.Mutex
+	lines      lineBuffer
+}
+
+func NewProcessingWriter(dst io.Writer, processors ...Processor) *ProcessingWriter {
+	return &ProcessingWriter{dst: dst, processors: processors}
+}
+
+func (w *ProcessingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(p), w.lines.write(p, w.writeLine)
+}
+
+func (w *ProcessingWriter) writeLine(line string) error {
+	rec := ParseTextLogLine(line)
+	for _, proc := range w.processors {
+		proc.Process(rec)
 	}
-	return len(p), nil
+	_, err := io.WriteString(w.dst, renderTextRecord(rec)+"\n")
+	return err
 }
 
-func ColorizeLogLine(line string) string {
+func (w *ColorizingWriter) WithParser(parser Parser) *ColorizingWriter {
+	w.parser = parser
+	return w
+}
+
+func (w *ColorizingWriter) colorize(line string) string {
+	pl := currentPipeline()
+	if pl == nil {
+		return line
+	}
+	if pl != w.base {
+		w.base, w.own = pl, pl.Fork()
+	}
+	if w.parser == nil {
+		return w.own.Colorize(line)
+	}
+	return w.own.Render(parseWith(w.parser, line))
+}
+
+func currentPipeline() *Pipeline {
 	stateMu.RLock()
-	pl := pipeline
-	stateMu.RUnlock()
+	defer stateMu.RUnlock()
+	return pipeline
+}
+
+func ColorizeRecord(rec *LogRecord) string {
+	if rec == nil {
+		return ""
+	}
+	pl := currentPipeline()
+	if pl == nil {
+		return rec.Raw
+	}
+	return pl.Fork().Render(rec)
+}
+
+func ColorizeLogLine(line string) string {
+	pl := currentPipeline()
 	if pl == nil {
 		return line
 	}
-	return pl.Colorize(line)
+	return pl.Fork().Colorize(line)
 }
 
 type aggregateEntry struct {
@@ -551,6 +725,7 @@ type AggregateLineWriter struct {
 	window time.Duration
 	keyFn  func(*LogRecord) string
 	remap  []LevelRemapRule
+	sink   string
 
 	mu    sync.Mutex
 	buf   []byte
@@ -567,6 +742,7 @@ func NewAggregateLineWriter(dst io.Writer, window time.Duration, keyFn func(*Log
 		window: window,
 		keyFn:  keyFn,
 		remap:  remap,
+		sink:   "aggregate",
 	}
 }
 
@@ -587,6 +763,30 @@ func (w *AggregateLineWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+func (w *AggregateLineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if len(w.buf) > 0 {
+		line := string(w.buf)
+		w.buf = nil
+		w.ingestLocked(line)
+		w.timer.Stop()
+	}
+	return w.flushLocked()
+}
+
+func flushSinks() {
+	stateMu.RLock()
+	aggs := aggregators
+	stateMu.RUnlock()
+	for _, w := range aggs {
+		_ = w.Flush()
+	}
+}
+
 func (w *AggregateLineWriter) ingestLocked(line string) {
 	rec := ParseTextLogLine(line)
 	applyLevelRemap(rec, w.remap)
@@ -602,6 +802,7 @@ func (w *AggregateLineWriter) ingestLocked(line string) {
 
 	if w.cur != nil && w.cur.key == key {
 		w.cur.count++
+		metrics.countSuppressed(w.sink)
 		w.resetTimerLocked()
 		return
 	}
@@ -652,7 +853,7 @@ func applyLevelRemap(rec *LogRecord, rules []LevelRemapRule) {
 		}
 		to := strings.TrimSpace(rule.To)
 		if to != "" {
-			rec.Level = strings.ToUpper(to)
+			rec.Level = canonicalLevel(to)
 		}
 		return
 	}
@@ -660,7 +861,7 @@ func applyLevelRemap(rec *LogRecord, rules []LevelRemapRule) {
 
 func matchesLevelRule(rec *LogRecord, rule LevelRemapRule) bool {
 	from := strings.TrimSpace(rule.From)
-	if from != "" && !strings.EqualFold(strings.TrimSpace(rec.Level), from) {
+	if from != "" && canonicalLevel(rec.Level) != canonicalLevel(from) {
 		return false
 	}
 	if len(rule.Contains) == 0 {
@@ -678,27 +879,3 @@ func matchesLevelRule(rec *LogRecord, rule LevelRemapRule) bool {
 	}
 	return true
 }
-
-func renderTextRecord(rec *LogRecord) string {
-	if rec == nil {
-		return ""
-	}
-	parts := make([]string, 0, 3+len(rec.Fields))
-	if rec.Time != "" {
-		parts = append(parts, "time="+rec.Time)
-	}
-	if rec.Level != "" {
-		parts = append(parts, "level="+strings.ToUpper(rec.Level))
-	}
-	if rec.Message != "" {
-		parts = append(parts, "msg="+rec.Message)
-	}
-	for _, f := range rec.Fields {
-		value := f.Value
-		if f.ValueOut != "" {
-			value = f.ValueOut
-		}
-		parts = append(parts, f.Key+"="+value)
-	}
-	return strings.Join(parts, " ")
-}
@@ -0,0 +1,246 @@
+package logging
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxMetricKeys  = 256
+	otherMetricKey = "(other)"
+)
+
+type Metrics struct {
+	mu         sync.Mutex
+	since      time.Time
+	levels     map[slog.Level]uint64
+	components map[string]uint64
+	messages   map[string]uint64
+	suppressed map[string]uint64
+	dropped    map[string]uint64
+	sinkErrors map[string]uint64
+}
+
+type MetricsSnapshot struct {
+	Since      time.Time         `json:"since"`
+	Levels     map[string]uint64 `json:"levels"`
+	Components map[string]uint64 `json:"components"`
+	Messages   map[string]uint64 `json:"messages"`
+	Suppressed map[string]uint64 `json:"dedupe_suppressed"`
+	Dropped    map[string]uint64 `json:"dropped"`
+	SinkErrors map[string]uint64 `json:"sink_write_errors"`
+}
+
+var metrics = NewMetrics()
+
+func LogMetrics() *Metrics { return metrics }
+
+func NewMetrics() *Metrics {
+	m := &Metrics{}
+	m.Reset()
+	return m
+}
+
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since = time.Now()
+	m.levels = map[slog.Level]uint64{}
+	m.components = map[string]uint64{}
+	m.messages = map[string]uint64{}
+	m.suppressed = map[string]uint64{}
+	m.dropped = map[string]uint64{}
+	m.sinkErrors = map[string]uint64{}
+}
+
+func (m *Metrics) countRecord(level slog.Level, component, msg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.levels[level]++
+	if component != "" {
+		bumpCapped(m.components, component)
+	}
+	bumpCapped(m.messages, msg)
+}
+
+func (m *Metrics) countSuppressed(sink string) {
+	m.mu.Lock()
+	m.suppressed[sink]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) countDropped(stage string) {
+	m.mu.Lock()
+	m.dropped[stage]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) countSinkError(sink string) {
+	m.mu.Lock()
+	m.sinkErrors[sink]++
+	m.mu.Unlock()
+}
+
+func bumpCapped(counts map[string]uint64, key string) {
+	if _, ok := counts[key]; !ok && len(counts) >= maxMetricKeys {
+		key = otherMetricKey
+	}
+	counts[key]++
+}
+
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := MetricsSnapshot{
+		Since:      m.since,
+		Levels:     map[string]uint64{},
+		Components: copyCounts(m.components),
+		Messages:   copyCounts(m.messages),
+		Suppressed: copyCounts(m.suppressed),
+		Dropped:    copyCounts(m.dropped),
+		SinkErrors: copyCounts(m.sinkErrors),
+	}
+	for _, spec := range Levels() {
+		s.Levels[spec.Name] = 0
+	}
+	for level, n := range m.levels {
+		s.Levels[LevelName(level)] += n
+	}
+	return s
+}
+
+func copyCounts(in map[string]uint64) map[string]uint64 {
+	out := make(map[string]uint64, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func (m *Metrics) String() string {
+	data, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+func PublishExpvar(name string) error {
+	return metrics.PublishExpvar(name)
+}
+
+func (m *Metrics) PublishExpvar(name string) error {
+	if strings.TrimSpace(name) == "" {
+		name = "consolex"
+	}
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %q is already published", name)
+	}
+	expvar.Publish(name, m)
+	return nil
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	s := m.Snapshot()
+	var b strings.Builder
+	writePromCounter(&b, "consolex_log_records_total", "Log records handled, by level.", "level", s.Levels)
+	writePromCounter(&b, "consolex_log_component_records_total", "Log records handled, by component.", "component", s.Components)
+	writePromCounter(&b, "consolex_log_message_records_total", "Log records handled, by message.", "message", s.Messages)
+	writePromCounter(&b, "consolex_log_dedupe_suppressed_total", "Repeated records folded into an xN line, by sink.", "sink", s.Suppressed)
+	writePromCounter(&b, "consolex_log_dropped_total", "Records not delivered, by stage.", "stage", s.Dropped)
+	writePromCounter(&b, "consolex_log_sink_write_errors_total", "Failed writes, by sink.", "sink", s.SinkErrors)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writePromCounter(b *strings.Builder, name, help, label string, counts map[string]uint64) {
+	_, _ = fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, key := range sortedKeys(counts) {
+		_, _ = fmt.Fprintf(b, "%s{%s=\"%s\"} %d\n", name, label, promEscaper.Replace(key), counts[key])
+	}
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func sortedKeys(counts map[string]uint64) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func topCounts(counts map[string]uint64, n int) []string {
+	keys := sortedKeys(counts)
+	sort.SliceStable(keys, func(i, j int) bool { return counts[keys[i]] > counts[keys[j]] })
+	if n > 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+func SessionSummary() string {
+	return summaryOf(metrics.Snapshot())
+}
+
+func summaryOf(s MetricsSnapshot) string {
+	parts := make([]string, 0, 4)
+	for _, spec := range Levels() {
+		if spec.Value < LevelWarn || s.Levels[spec.Name] == 0 {
+			continue
+		}
+		parts = append(parts, pluralLevel(spec.Name, s.Levels[spec.Name]))
+	}
+	if len(parts) == 0 {
+		return "no warnings or errors"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func LogSessionSummary() {
+	s := metrics.Snapshot()
+	var total uint64
+	for _, n := range s.Levels {
+		total += n
+	}
+	slog.Info("session summary", "records", total, "uptime", time.Since(s.Since).Round(time.Second), "summary", summaryOf(s))
+}
+
+func pluralLevel(name string, n uint64) string {
+	word := strings.ToLower(name)
+	if name == "WARN" {
+		word = "warning"
+	}
+	if n == 1 {
+		return "1 " + word
+	}
+	return strconv.FormatUint(n, 10) + " " + word + "s"
+}
+
+type sinkWriter struct {
+	sink string
+	dst  io.Writer
+}
+
+func (w sinkWriter) Write(p []byte) (int, error) {
+	n, err := w.dst.Write(p)
+	if err != nil {
+		metrics.countSinkError(w.sink)
+	}
+	return n, err
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"expvar"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestComponentCountsTopLevelOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *slog.Logger)
+		want string
+	}{
+		{"record attr", func(l *slog.Logger) { l.Info("m", "component", "world") }, "world"},
+		{"logger attr", func(l *slog.Logger) { l.With("component", "net").Info("m") }, "net"},
+		{"record overrides logger", func(l *slog.Logger) { l.With("component", "net").Info("m", "component", "db") }, "db"},
+		{"inside group attr", func(l *slog.Logger) { l.Info("m", slog.Group("req", "component", "x")) }, ""},
+		{"after WithGroup", func(l *slog.Logger) { l.WithGroup("req").Info("m", "component", "x") }, ""},
+		{"logger attr after WithGroup", func(l *slog.Logger) { l.WithGroup("req").With("component", "x").Info("m") }, ""},
+		{"kept through WithGroup", func(l *slog.Logger) { l.With("component", "net").WithGroup("req").Info("m", "component", "x") }, "net"},
+	}
+	old := metrics
+	defer func() { metrics = old }()
+	for _, tt := range tests {
+		metrics = NewMetrics()
+		tt.log(slog.New(fanoutHandler{handlers: []slog.Handler{slog.NewTextHandler(io.Discard, nil)}}))
+		got := ""
+		for k := range metrics.Snapshot().Components {
+			got = k
+		}
+		if got != tt.want {
+			t.Errorf("%s: component = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsPublishAndDrops(t *testing.T) {
+	m := NewMetrics()
+	if err := m.PublishExpvar("consolex_test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PublishExpvar("consolex_test"); err == nil {
+		t.Fatal("second publish under the same name succeeded")
+	}
+	m.countDropped("follow")
+	if v := expvar.Get("consolex_test").String(); !strings.Contains(v, `"dropped":{"follow":1}`) {
+		t.Fatalf("expvar = %s", v)
+	}
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `consolex_log_dropped_total{stage="follow"} 1`) {
+		t.Fatalf("prometheus output missing drop counter:\n%s", b.String())
+	}
+}
+
+func TestRingCountsFollowerDrops(t *testing.T) {
+	old := metrics
+	metrics = NewMetrics()
+	defer func() { metrics = old }()
+	ring := NewRecordRing(4)
+	ring.mu.Lock()
+	ring.followers[0] = make(chan RingRecord)
+	ring.mu.Unlock()
+	ring.Add(&LogRecord{Message: "x"})
+	if got := metrics.Snapshot().Dropped["follow"]; got != 1 {
+		t.Fatalf("dropped = %d, want 1", got)
+	}
+}
@@ -0,0 +1,265 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Parser interface {
+	Parse(line string) (*LogRecord, bool)
+}
+
+type ParserFunc func(line string) (*LogRecord, bool)
+
+func (f ParserFunc) Parse(line string) (*LogRecord, bool) {
+	return f(line)
+}
+
+var (
+	SlogTextParser Parser = ParserFunc(parseSlogText)
+	LogfmtParser   Parser = ParserFunc(parseLogfmt)
+	JSONParser     Parser = ParserFunc(parseJSON)
+	GoLogParser    Parser = ParserFunc(parseGoLog)
+	RawParser      Parser = ParserFunc(parseRaw)
+	AutoParser            = NewAutoParser(JSONParser, GoLogParser, SlogTextParser, LogfmtParser)
+)
+
+var (
+	timeKeys  = []string{"time", "ts", "t", "timestamp", "@timestamp"}
+	levelKeys = []string{"level", "lvl", "severity", "@level"}
+	msgKeys   = []string{"msg", "message", "@message"}
+)
+
+var levelAliases = map[string]string{
+	"DBG":      "DEBUG",
+	"INF":      "INFO",
+	"WRN":      "WARN",
+	"WARNING":  "WARN",
+	"ERR":      "ERROR",
+	"CRIT":     "FATAL",
+	"CRITICAL": "FATAL",
+	"PANIC":    "FATAL",
+}
+
+func NewAutoParser(parsers ...Parser) Parser {
+	return ParserFunc(func(line string) (*LogRecord, bool) {
+		for _, p := range parsers {
+			if rec, ok := p.Parse(line); ok {
+				return rec, true
+			}
+		}
+		return parseRaw(line)
+	})
+}
+
+func parseSlogText(line string) (*LogRecord, bool) {
+	rec := ParseTextLogLine(line)
+	return rec, len(rec.head) > 0
+}
+
+func parseLogfmt(line string) (*LogRecord, bool) {
+	rec := ParseTextLogLine(line)
+	if len(rec.Fields) == 0 && len(rec.head) == 0 {
+		return nil, false
+	}
+	for _, f := range rec.Fields {
+		if f.src != nil && f.src.bare {
+			return nil, false
+		}
+	}
+	promoteHeads(rec)
+	return rec, true
+}
+
+func promoteHeads(rec *LogRecord) {
+	promos := [...]struct {
+		name string
+		keys []string
+		set  func(string)
+	}{
+		{"time", timeKeys, func(v string) { rec.Time = v }},
+		{"level", levelKeys, func(v string) { rec.Level = foreignLevel(v) }},
+		{"msg", msgKeys, func(v string) { rec.Message = v }},
+	}
+	taken := map[int]string{}
+	for _, p := range promos {
+		if rec.hasHead(p.name) {
+			continue
+		}
+		if i := findField(rec.Fields, p.keys, taken); i >= 0 {
+			p.set(rec.Fields[i].Value)
+			taken[i] = p.name
+		}
+	}
+	if len(taken) == 0 {
+		return
+	}
+	kept := make([]RecordField, 0, len(rec.Fields)-len(taken))
+	newPos := make([]int, len(rec.Fields)+1)
+	order := make(map[*textToken]int, len(rec.head)+len(taken))
+	for _, h := range rec.head {
+		order[h.tok] = 2*h.pos - 1
+	}
+	for i, f := range rec.Fields {
+		newPos[i] = len(kept)
+		name, ok := taken[i]
+		switch {
+		case !ok:
+			kept = append(kept, f)
+		case f.src != nil:
+			rec.head = append(rec.head, headToken{name: name, tok: f.src, pos: i})
+			order[f.src] = 2 * i
+		}
+	}
+	newPos[len(rec.Fields)] = len(kept)
+	sort.SliceStable(rec.head, func(i, j int) bool { return order[rec.head[i].tok] < order[rec.head[j].tok] })
+	for i := range rec.head {
+		rec.head[i].pos = newPos[rec.head[i].pos]
+	}
+	rec.Fields = kept
+}
+
+func findField(fields []RecordField, keys []string, taken map[int]string) int {
+	for _, key := range keys {
+		for i, f := range fields {
+			if _, used := taken[i]; !used && f.Key == key {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func foreignLevel(v string) string {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	if _, ok := ParseLevel(v); ok {
+		return canonicalLevel(v)
+	}
+	if alias, ok := levelAliases[v]; ok {
+		return alias
+	}
+	return v
+}
+
+func InferLevel(text string) string {
+	word := strings.TrimSpace(text)
+	if i := strings.IndexAny(word, " \t"); i > 0 {
+		word = word[:i]
+	}
+	word = strings.Trim(word, "[]():|<>")
+	if word == "" {
+		return ""
+	}
+	if alias, ok := levelAliases[strings.ToUpper(word)]; ok {
+		return alias
+	}
+	levelsMu.RLock()
+	spec, ok := levels[strings.ToUpper(word)]
+	levelsMu.RUnlock()
+	if ok {
+		return spec.Name
+	}
+	return ""
+}
+
+func parseJSON(line string) (*LogRecord, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+		return nil, false
+	}
+	rec := &LogRecord{Raw: line, Fields: make([]RecordField, 0, 16)}
+	if err := flattenJSON(rec, "", json.RawMessage(trimmed)); err != nil {
+		return nil, false
+	}
+	promoteHeads(rec)
+	return rec, true
+}
+
+func flattenJSON(rec *LogRecord, prefix string, raw json.RawMessage) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := prefix + tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if len(value) > 0 && value[0] == '{' {
+			if err := flattenJSON(rec, key+".", value); err != nil {
+				return err
+			}
+			continue
+		}
+		rec.Fields = append(rec.Fields, jsonField(key, value))
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("trailing data after JSON object")
+	}
+	return nil
+}
+
+func jsonField(key string, raw json.RawMessage) RecordField {
+	f := RecordField{Key: key, ShowKey: true}
+	switch raw[0] {
+	case '"':
+		var s string
+		_ = json.Unmarshal(raw, &s)
+		f.Value = s
+		f.Kind = kindOf(s, true)
+		f.Quoted = needsQuoting(s)
+	case '[':
+		var b bytes.Buffer
+		if json.Compact(&b, raw) == nil {
+			raw = b.Bytes()
+		}
+		f.Value = string(raw)
+		f.Kind = KindText
+		f.Quoted = needsQuoting(f.Value)
+	case 'n':
+		f.Value = "<nil>"
+		f.Kind = KindNil
+	default:
+		f.Value = string(raw)
+		f.Kind = kindOf(f.Value, false)
+	}
+	return f
+}
+
+var goLogPattern = regexp.MustCompile(`^(\S+ )??((\d{4}/\d{2}/\d{2}) )?(\d{2}:\d{2}:\d{2}(\.\d+)?) (([^\s:]+\.go:\d+): )?(.*)$`)
+
+func parseGoLog(line string) (*LogRecord, bool) {
+	m := goLogPattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil, false
+	}
+	rec := &LogRecord{Raw: line, Fields: make([]RecordField, 0, 2)}
+	rec.Time = strings.TrimSpace(m[3] + " " + m[4])
+	rec.Message = m[8]
+	rec.Level = InferLevel(rec.Message)
+	if prefix := strings.TrimSpace(m[1]); prefix != "" {
+		rec.Fields = append(rec.Fields, RecordField{Key: "prefix", Value: prefix, Kind: KindText, ShowKey: true})
+	}
+	if m[7] != "" {
+		rec.Fields = append(rec.Fields, RecordField{Key: "source", Value: m[7], Kind: KindPath, ShowKey: true})
+	}
+	return rec, true
+}
+
+func parseRaw(line string) (*LogRecord, bool) {
+	return &LogRecord{Raw: line, Message: line}, true
+}
@@ -0,0 +1,201 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+var textSeeds = []struct{ msg, key, value string }{
+	{"hello", "player", "steve"},
+	{"quoted value", "reason", "lost connection"},
+	{"a=b", "k=v", "x=y"},
+	{`say "hi"`, "quote", `"already quoted"`},
+	{`back\slash`, "path", `C:\tmp\x`},
+	{"line\nbreak", "stack", "a\nb\tc"},
+	{"", "empty", ""},
+	{"bad \xff utf8", "raw", "\xc3\x28"},
+	{"msg", "msg", "dup"},
+	{"  padded  ", "level", "WARNING"},
+}
+
+func slogTextLine(t testing.TB, msg, key, value string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: LevelTrace})
+	rec := slog.NewRecord(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), slog.LevelWarn, msg, 0)
+	rec.AddAttrs(slog.String(key, value), slog.Int("n", 1))
+	if err := h.Handle(context.Background(), rec); err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func FuzzParseTextLogLine(f *testing.F) {
+	for _, s := range textSeeds {
+		f.Add(s.msg, s.key, s.value)
+	}
+	f.Fuzz(func(t *testing.T, msg, key, value string) {
+		if key == "" {
+			return
+		}
+		line := slogTextLine(t, msg, key, value)
+		rec := ParseTextLogLine(line)
+		if rec.Time != "2026-01-02T15:04:05.000Z" || rec.Level != "WARN" || rec.Message != msg {
+			t.Fatalf("%q: head = %q %q %q", line, rec.Time, rec.Level, rec.Message)
+		}
+		if len(rec.Fields) != 2 || rec.Fields[0].Key != key || rec.Fields[0].Value != value {
+			t.Fatalf("%q: fields = %+v", line, rec.Fields)
+		}
+		if got := renderTextRecord(rec); got != line {
+			t.Fatalf("round trip\n got %q\nwant %q", got, line)
+		}
+	})
+}
+
+func FuzzRenderRecord(f *testing.F) {
+	for _, s := range textSeeds {
+		f.Add(slogTextLine(f, s.msg, s.key, s.value))
+	}
+	for _, line := range []string{
+		"",
+		"   ",
+		"  lead and tail  ",
+		`bare "quoted bare" key= ="x"`,
+		`"weird key"=1 msg="unterminated`,
+		`level=err time=x msg=a level=b`,
+		"a==b c=\"d\\\"e\" f=\xff",
+	} {
+		f.Add(line)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		rec := ParseTextLogLine(line)
+		if got := renderTextRecord(rec); got != line {
+			t.Fatalf("unchanged record re-rendered\n got %q\nwant %q", got, line)
+		}
+		rec.Message += "!"
+		again := ParseTextLogLine(renderTextRecord(rec))
+		if again.Message != rec.Message {
+			t.Fatalf("edited message %q parsed back as %q", rec.Message, again.Message)
+		}
+	})
+}
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		line             string
+		time, level, msg string
+		fields           []string
+	}{
+		{
+			line:   `ts=2026-01-02T15:04:05Z lvl=warning message="disk low" free=3`,
+			time:   "2026-01-02T15:04:05Z",
+			level:  "WARN",
+			msg:    "disk low",
+			fields: []string{"free=3"},
+		},
+		{
+			line:   `a=1 message=hi b=2 severity=err c=3 t=now d=4`,
+			time:   "now",
+			level:  "ERROR",
+			msg:    "hi",
+			fields: []string{"a=1", "b=2", "c=3", "d=4"},
+		},
+		{
+			line:   `msg=first lvl=debug message=second`,
+			level:  "DEBUG",
+			msg:    "first",
+			fields: []string{"message=second"},
+		},
+		{
+			line:   `time=x msg=m ts=y`,
+			time:   "x",
+			msg:    "m",
+			fields: []string{"ts=y"},
+		},
+	}
+	for _, tt := range tests {
+		rec, ok := LogfmtParser.Parse(tt.line)
+		if !ok {
+			t.Fatalf("%q: not parsed", tt.line)
+		}
+		if rec.Time != tt.time || rec.Level != tt.level || rec.Message != tt.msg {
+			t.Errorf("%q: head = %q %q %q", tt.line, rec.Time, rec.Level, rec.Message)
+		}
+		var got []string
+		for _, f := range rec.Fields {
+			got = append(got, f.Key+"="+f.Value)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.fields, " ") {
+			t.Errorf("%q: fields = %v, want %v", tt.line, got, tt.fields)
+		}
+		for i := 1; i < len(rec.head); i++ {
+			if rec.head[i].pos < rec.head[i-1].pos {
+				t.Errorf("%q: head out of order: %+v", tt.line, rec.head)
+			}
+		}
+		if out := renderTextRecord(rec); out != tt.line {
+			t.Errorf("%q: re-rendered as %q", tt.line, out)
+		}
+	}
+	for _, line := range []string{"", "just words", "a=1 bare"} {
+		if _, ok := LogfmtParser.Parse(line); ok {
+			t.Errorf("%q: parsed as logfmt", line)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		line             string
+		ok               bool
+		time, level, msg string
+		fields           []string
+	}{
+		{
+			line:   `{"time":"2026-01-02T15:04:05Z","level":"WARNING","msg":"hot","temp":91.5}`,
+			ok:     true,
+			time:   "2026-01-02T15:04:05Z",
+			level:  "WARN",
+			msg:    "hot",
+			fields: []string{"temp=91.5"},
+		},
+		{
+			line:   `  {"@message":"x","req":{"id":7,"tags":["a", "b"]},"err":null}  `,
+			ok:     true,
+			msg:    "x",
+			fields: []string{"req.id=7", `req.tags=["a","b"]`, "err=<nil>"},
+		},
+		{line: `{"a":1} garbage}`},
+		{line: `{"a":1} {"b":2}`},
+		{line: `{"a":1}}`},
+		{line: `{"a":}`},
+		{line: `not json`},
+	}
+	for _, tt := range tests {
+		rec, ok := JSONParser.Parse(tt.line)
+		if ok != tt.ok {
+			t.Errorf("%q: ok = %v", tt.line, ok)
+			continue
+		}
+		if !ok {
+			if got := parseWith(AutoParser, tt.line); got.Message != tt.line && got.Message != strings.TrimSpace(tt.line) {
+				t.Errorf("%q: auto fallback message = %q", tt.line, got.Message)
+			}
+			continue
+		}
+		if rec.Time != tt.time || rec.Level != tt.level || rec.Message != tt.msg {
+			t.Errorf("%q: head = %q %q %q", tt.line, rec.Time, rec.Level, rec.Message)
+		}
+		var got []string
+		for _, f := range rec.Fields {
+			got = append(got, f.Key+"="+f.Value)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.fields, " ") {
+			t.Errorf("%q: fields = %v, want %v", tt.line, got, tt.fields)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package logging
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/VexoraDevelopment/consolex/style"
+	"github.com/VexoraDevelopment/consolex/term"
+)
+
+const (
+	defaultMessageWidth = 40
+	minMessageWidth     = 16
+	minFieldsWidth      = 24
+)
+
+type defaultRenderer struct {
+	theme     style.Theme
+	profile   Profile
+	width     func() int
+	timeWidth *atomic.Int64
+	times     *timeFormatter
+}
+
+func newDefaultRenderer(theme style.Theme, profile Profile) Renderer {
+	profile = normalizeProfile(profile)
+	return defaultRenderer{
+		theme:     theme,
+		profile:   profile,
+		width:     term.ConsoleWidth,
+		timeWidth: new(atomic.Int64),
+		times:     newTimeFormatter(profile),
+	}
+}
+
+func (r defaultRenderer) fork() Renderer {
+	r.timeWidth = new(atomic.Int64)
+	r.times = newTimeFormatter(r.profile)
+	return r
+}
+
+func (r defaultRenderer) Render(rec *LogRecord) string {
+	var timeStr, levelStr, msg string
+	if rec.Time != "" {
+		timeStr = r.theme.Style("time").Dim().Wrap(r.times.format(rec.Time, ""))
+	}
+	if rec.Level != "" {
+		levelStr = r.levelBadge(rec.Level)
+	}
+	if rec.Message != "" {
+		msg = wrapOverride(r.theme, "msg", rec.Message)
+	}
+	fields := r.renderFields(rec.Fields)
+	if r.profile.Aligned && (timeStr != "" || levelStr != "") {
+		line, indent := r.renderAligned(timeStr, levelStr, msg, fields)
+		return r.wrap(line, indent)
+	}
+	parts := make([]string, 0, 3+len(fields))
+	indent := 0
+	for _, part := range []string{timeStr, levelStr} {
+		if part != "" {
+			parts = append(parts, part)
+			indent += style.Width(part) + 1
+		}
+	}
+	if msg != "" {
+		parts = append(parts, msg)
+	}
+	return r.wrap(strings.Join(append(parts, fields...), " "), indent)
+}
+
+func (r defaultRenderer) wrap(line string, indent int) string {
+	if !r.profile.WrapLines {
+		return line
+	}
+	w := r.width()
+	if w <= 0 || style.Width(line) <= w {
+		return line
+	}
+	if indent > w/2 {
+		indent = 2
+	}
+	return style.Wrap(line, w, indent)
+}
+
+func (r defaultRenderer) renderFields(fields []RecordField) []string {
+	fields, more := arrangeFields(fields, r.profile)
+	parts := make([]string, 0, len(fields)+1)
+	for _, f := range fields {
+		value := f.displayValue()
+		if f.Styled {
+			value = f.Style.Wrap(value)
+		}
+		showKey := f.ShowKey && f.Key != ""
+		if r.profile.CompactMode {
+			if hidden, ok := r.profile.HideKeys[f.Key]; ok && hidden {
+				showKey = false
+			}
+		}
+		if !showKey {
+			parts = append(parts, value)
+			continue
+		}
+		parts = append(parts, wrapOverride(r.theme, "field.key", f.Key)+"="+value)
+	}
+	if more > 0 {
+		parts = append(parts, r.theme.Style("muted").Wrap(moreFieldsMarker(more)))
+	}
+	return parts
+}
+
+func (r defaultRenderer) renderAligned(timeStr, levelStr, msg string, fields []string) (string, int) {
+	var b strings.Builder
+	used := 0
+	if timeStr != "" {
+		tw := int64(style.Width(timeStr))
+		for {
+			cur := r.timeWidth.Load()
+			if tw <= cur || r.timeWidth.CompareAndSwap(cur, tw) {
+				break
+			}
+		}
+		col := int(r.timeWidth.Load())
+		b.WriteString(style.PadRight(timeStr, col))
+		b.WriteByte(' ')
+		used += col + 1
+	}
+	if levelStr != "" {
+		b.WriteString(levelStr)
+		b.WriteByte(' ')
+		used += max(style.Width(levelStr), r.levelWidth()) + 1
+	}
+	if len(fields) == 0 {
+		b.WriteString(msg)
+		return strings.TrimRight(b.String(), " "), used
+	}
+	msgW := r.profile.MessageWidth
+	if tw := r.width(); tw > 0 {
+		if avail := tw - used - 1 - minFieldsWidth; avail < msgW {
+			msgW = max(avail, minMessageWidth)
+		}
+	}
+	b.WriteString(style.PadRight(style.Truncate(msg, msgW, "…"), msgW))
+	b.WriteByte(' ')
+	b.WriteString(strings.Join(fields, " "))
+	return b.String(), used
+}
+
+func (r defaultRenderer) levelBadge(level string) string {
+	label := r.levelLabel(level)
+	if r.profile.Aligned {
+		label = style.PadRight(label, r.levelWidth())
+	}
+	return r.levelStyle(level).Wrap(label)
+}
+
+func (r defaultRenderer) levelWidth() int {
+	w := 0
+	for _, label := range r.profile.LevelLabels {
+		w = max(w, style.Width(label))
+	}
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	for name, spec := range levels {
+		if _, ok := r.profile.LevelLabels[name]; !ok {
+			w = max(w, style.Width(spec.Label))
+		}
+	}
+	return w
+}
+
+func (r defaultRenderer) levelLabel(level string) string {
+	lvl := canonicalLevel(level)
+	if l, ok := r.profile.LevelLabels[lvl]; ok {
+		return l
+	}
+	if spec, ok := LookupLevel(lvl); ok {
+		return spec.Label
+	}
+	return lvl
+}
+
+func (r defaultRenderer) levelStyle(level string) style.Chalk {
+	lvl := canonicalLevel(level)
+	if st, ok := r.theme.Lookup("level." + strings.ToLower(lvl)); ok {
+		return st
+	}
+	return r.theme.Style(levelToken(lvl))
+}
+
+func wrapOverride(theme style.Theme, token, s string) string {
+	if st, ok := theme.Override(token); ok {
+		return st.Wrap(s)
+	}
+	return s
+}
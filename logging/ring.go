@@ -0,0 +1,275 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRingSize     = 256
+	defaultFollowBuffer = 64
+)
+
+type RingRecord struct {
+	Seq    uint64
+	Time   time.Time
+	Level  slog.Level
+	Record *LogRecord
+}
+
+type RecordQuery struct {
+	MinLevel slog.Leveler
+	Since    time.Time
+	Until    time.Time
+	Message  string
+	Pattern  *regexp.Regexp
+	Fields   map[string]string
+	Terms    []string
+	AfterSeq uint64
+	Limit    int
+}
+
+type RecordRing struct {
+	mu        sync.Mutex
+	entries   []RingRecord
+	next      int
+	full      bool
+	seq       uint64
+	split     lineBuffer
+	followers map[int]chan RingRecord
+	nextID    int
+}
+
+var recent = NewRecordRing(defaultRingSize)
+
+func RecentRecords() *RecordRing { return recent }
+
+func NewRecordRing(size int) *RecordRing {
+	if size <= 0 {
+		size = defaultRingSize
+	}
+	return &RecordRing{entries: make([]RingRecord, size), followers: map[int]chan RingRecord{}}
+}
+
+func (r *RecordRing) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(p), r.split.write(p, func(line string) error {
+		r.addLocked(ParseTextLogLine(line))
+		return nil
+	})
+}
+
+func (r *RecordRing) Add(rec *LogRecord) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addLocked(rec)
+}
+
+func (r *RecordRing) addLocked(rec *LogRecord) uint64 {
+	r.seq++
+	e := RingRecord{Seq: r.seq, Time: time.Now(), Record: rec}
+	if t, ok := recordTime(rec); ok {
+		e.Time = t
+	}
+	if l, ok := ParseLevel(rec.Level); ok {
+		e.Level = l
+	}
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+	for _, ch := range r.followers {
+		select {
+		case ch <- e.clone():
+		default:
+			metrics.countDropped("follow")
+		}
+	}
+	return e.Seq
+}
+
+func (r *RecordRing) Resize(n int) {
+	if n <= 0 {
+		n = defaultRingSize
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n == len(r.entries) {
+		return
+	}
+	old := r.snapshotLocked()
+	if len(old) > n {
+		old = old[len(old)-n:]
+	}
+	r.entries = make([]RingRecord, n)
+	r.next, r.full = copy(r.entries, old)%n, len(old) == n
+}
+
+func (r *RecordRing) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.full {
+		return len(r.entries)
+	}
+	return r.next
+}
+
+func (r *RecordRing) Cap() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+func (r *RecordRing) LastSeq() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seq
+}
+
+func (r *RecordRing) oldestTime() (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case r.full:
+		return r.entries[r.next].Time, true
+	case r.next > 0:
+		return r.entries[0].Time, true
+	}
+	return time.Time{}, false
+}
+
+func (r *RecordRing) Get(seq uint64) (RingRecord, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.snapshotLocked() {
+		if e.Seq == seq {
+			return e.clone(), true
+		}
+	}
+	return RingRecord{}, false
+}
+
+func (r *RecordRing) Tail(n int) []RingRecord {
+	return r.Query(RecordQuery{Limit: n})
+}
+
+func (r *RecordRing) Query(q RecordQuery) []RingRecord {
+	r.mu.Lock()
+	all := r.snapshotLocked()
+	r.mu.Unlock()
+	out := make([]RingRecord, 0, len(all))
+	for _, e := range all {
+		if q.Match(e) {
+			out = append(out, e)
+		}
+	}
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[len(out)-q.Limit:]
+	}
+	for i := range out {
+		out[i] = out[i].clone()
+	}
+	return out
+}
+
+func (r *RecordRing) Follow(ctx context.Context, q RecordQuery) <-chan RingRecord {
+	out := make(chan RingRecord, defaultFollowBuffer)
+	in := make(chan RingRecord, defaultFollowBuffer)
+	r.mu.Lock()
+	id := r.nextID
+	r.nextID++
+	r.followers[id] = in
+	r.mu.Unlock()
+	go func() {
+		defer close(out)
+		defer func() {
+			r.mu.Lock()
+			delete(r.followers, id)
+			r.mu.Unlock()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-in:
+				if !q.Match(e) {
+					continue
+				}
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+func (r *RecordRing) snapshotLocked() []RingRecord {
+	if !r.full {
+		return append([]RingRecord(nil), r.entries[:r.next]...)
+	}
+	out := make([]RingRecord, 0, len(r.entries))
+	out = append(out, r.entries[r.next:]...)
+	return append(out, r.entries[:r.next]...)
+}
+
+func (q RecordQuery) Match(e RingRecord) bool {
+	rec := e.Record
+	if rec == nil {
+		return false
+	}
+	if e.Seq <= q.AfterSeq {
+		return false
+	}
+	if q.MinLevel != nil && e.Level < q.MinLevel.Level() {
+		return false
+	}
+	if !q.Since.IsZero() && e.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && e.Time.After(q.Until) {
+		return false
+	}
+	if q.Message != "" && !strings.Contains(strings.ToLower(rec.Message), strings.ToLower(q.Message)) {
+		return false
+	}
+	if q.Pattern != nil && !q.Pattern.MatchString(rec.Raw) {
+		return false
+	}
+	for key, want := range q.Fields {
+		found := false
+		for _, f := range rec.Fields {
+			if f.Key == key && (want == "" || f.Value == want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchTerms(rec, q.Terms)
+}
+
+func (e RingRecord) clone() RingRecord {
+	e.Record = e.Record.Clone()
+	return e
+}
+
+func (rec *LogRecord) Clone() *LogRecord {
+	if rec == nil {
+		return nil
+	}
+	out := *rec
+	out.Fields = append([]RecordField(nil), rec.Fields...)
+	out.head = append([]headToken(nil), rec.head...)
+	return &out
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/VexoraDevelopment/consolex/term"
+)
+
+type stdioCapture struct {
+	outW, errW *os.File
+	sinks      []*CaptureWriter
+	done       sync.WaitGroup
+}
+
+var (
+	stdioMu sync.Mutex
+	stdio   *stdioCapture
+)
+
+func CaptureStdio(cfg CaptureConfig) error {
+	stdioMu.Lock()
+	defer stdioMu.Unlock()
+	if stdio != nil {
+		return nil
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		_ = outR.Close()
+		_ = outW.Close()
+		return err
+	}
+	outLevel, errLevel := slog.LevelInfo, slog.LevelWarn
+	if cfg.Level != nil {
+		outLevel = cfg.Level.Level()
+	}
+	if cfg.StderrLevel != nil {
+		errLevel = cfg.StderrLevel.Level()
+	}
+	outCfg, errCfg := cfg, cfg
+	if cfg.Component == "" {
+		outCfg.Component, errCfg.Component = "stdout", "stderr"
+	}
+	if err := term.RedirectStdio(outW, errW); err != nil {
+		for _, f := range []*os.File{outR, outW, errR, errW} {
+			_ = f.Close()
+		}
+		return err
+	}
+	c := &stdioCapture{
+		outW:  outW,
+		errW:  errW,
+		sinks: []*CaptureWriter{NewCaptureWriter(outCfg, outLevel), NewCaptureWriter(errCfg, errLevel)},
+	}
+	for i, r := range []*os.File{outR, errR} {
+		c.done.Add(1)
+		go func(r *os.File, w *CaptureWriter) {
+			defer c.done.Done()
+			_, _ = w.ReadFrom(r)
+			_ = r.Close()
+		}(r, c.sinks[i])
+	}
+	stdio = c
+	return nil
+}
+
+func RestoreStdio() error {
+	stdioMu.Lock()
+	c := stdio
+	stdio = nil
+	stdioMu.Unlock()
+	if c == nil {
+		return nil
+	}
+	err := errors.Join(term.RestoreStdio(), c.outW.Close(), c.errW.Close())
+	c.done.Wait()
+	return err
+}
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/VexoraDevelopment/consolex/term"
+)
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestCaptureStdioRedirectsDescriptors(t *testing.T) {
+	var buf lockedBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	origOut := term.Stdout()
+	if err := CaptureStdio(CaptureConfig{Logger: logger}); err != nil {
+		t.Fatal(err)
+	}
+	if term.Stdout() == origOut {
+		_ = RestoreStdio()
+		t.Fatal("term.Stdout still points at the redirected descriptor")
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCaptureHelperProcess$")
+	cmd.Env = append(os.Environ(), "CONSOLEX_CAPTURE_HELPER=1")
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	runErr := cmd.Run()
+	if err := RestoreStdio(); err != nil {
+		t.Fatal(err)
+	}
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	if term.Stdout() != os.Stdout {
+		t.Fatal("term.Stdout not restored")
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"msg=boom component=stdout",
+		`msg="partial line" component=stdout`,
+		`msg="warn tail" component=stderr`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in\n%s", want, got)
+		}
+	}
+}
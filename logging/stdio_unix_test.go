@@ -0,0 +1,42 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package logging
+
+import (
+	"log/slog"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestCaptureStdioRawDescriptor(t *testing.T) {
+	var buf lockedBuffer
+	if err := CaptureStdio(CaptureConfig{Logger: slog.New(slog.NewTextHandler(&buf, nil))}); err != nil {
+		t.Fatal(err)
+	}
+	_, werr := syscall.Write(syscall.Stdout, []byte("raw write\n"))
+	if err := RestoreStdio(); err != nil {
+		t.Fatal(err)
+	}
+	if werr != nil {
+		t.Fatal(werr)
+	}
+	if got := buf.String(); !strings.Contains(got, `msg="raw write" component=stdout`) {
+		t.Fatalf("raw fd write not captured:\n%s", got)
+	}
+}
+
+func TestCommandsBuiltBeforeCaptureBypassIt(t *testing.T) {
+	cmd := LevelCommand(nil)
+	var buf lockedBuffer
+	if err := CaptureStdio(CaptureConfig{Logger: slog.New(slog.NewTextHandler(&buf, nil))}); err != nil {
+		t.Fatal(err)
+	}
+	cmd.Execute("")
+	if err := RestoreStdio(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); strings.Contains(got, "level:") {
+		t.Fatalf("command output was captured as a log record:\n%s", got)
+	}
+}
@@ -0,0 +1,305 @@
+package logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+type templateAlign byte
+
+const (
+	alignNone   templateAlign = 0
+	alignLeft   templateAlign = '<'
+	alignRight  templateAlign = '>'
+	alignCenter templateAlign = '^'
+)
+
+type templateDirective struct {
+	name     string
+	arg      string
+	style    style.Chalk
+	styled   bool
+	align    templateAlign
+	width    int
+	truncate int
+}
+
+type templateNode struct {
+	text    string
+	dir     *templateDirective
+	section []templateNode
+}
+
+type TemplateRenderer struct {
+	base   defaultRenderer
+	nodes  []templateNode
+	picked map[string]bool
+}
+
+func NewTemplateRenderer(format string, theme style.Theme, profile Profile) (*TemplateRenderer, error) {
+	nodes, err := parseTemplate(format)
+	if err != nil {
+		return nil, err
+	}
+	r := &TemplateRenderer{
+		base:   newDefaultRenderer(theme, profile).(defaultRenderer),
+		nodes:  nodes,
+		picked: map[string]bool{},
+	}
+	r.base.profile.Aligned = false
+	collectPickedFields(nodes, r.picked)
+	return r, nil
+}
+
+func (r *TemplateRenderer) fork() Renderer {
+	out := *r
+	out.base = r.base.fork().(defaultRenderer)
+	return &out
+}
+
+func collectPickedFields(nodes []templateNode, picked map[string]bool) {
+	for _, n := range nodes {
+		if n.dir != nil && n.dir.name == "field" {
+			picked[n.dir.arg] = true
+		}
+		collectPickedFields(n.section, picked)
+	}
+}
+
+func (r *TemplateRenderer) Render(rec *LogRecord) string {
+	out, _ := r.renderNodes(r.nodes, rec)
+	return out
+}
+
+func (r *TemplateRenderer) renderNodes(nodes []templateNode, rec *LogRecord) (string, bool) {
+	var b strings.Builder
+	complete := true
+	for _, n := range nodes {
+		switch {
+		case n.dir != nil:
+			v := r.renderDirective(n.dir, rec)
+			if v == "" {
+				complete = false
+			}
+			b.WriteString(v)
+		case n.section != nil:
+			if v, ok := r.renderNodes(n.section, rec); ok {
+				b.WriteString(v)
+			}
+		default:
+			b.WriteString(n.text)
+		}
+	}
+	return b.String(), complete
+}
+
+func (r *TemplateRenderer) renderDirective(d *templateDirective, rec *LogRecord) string {
+	var v string
+	switch d.name {
+	case "time":
+		v = r.base.times.format(rec.Time, d.arg)
+		if v != "" && !d.styled {
+			v = r.base.theme.Style("time").Dim().Wrap(v)
+		}
+	case "level":
+		v = r.renderLevel(rec.Level, d.arg)
+	case "msg":
+		v = rec.Message
+		if v != "" && !d.styled {
+			v = wrapOverride(r.base.theme, "msg", v)
+		}
+	case "fields":
+		rest := make([]RecordField, 0, len(rec.Fields))
+		for _, f := range rec.Fields {
+			if !r.picked[f.Key] {
+				rest = append(rest, f)
+			}
+		}
+		v = strings.Join(r.base.renderFields(rest), " ")
+	case "field":
+		for _, f := range rec.Fields {
+			if f.Key != d.arg {
+				continue
+			}
+			if limit := maxValueLen(r.base.profile, f.Key); limit > 0 {
+				f = elideField(f, limit)
+			}
+			v = f.displayValue()
+			if f.Styled && !d.styled {
+				v = f.Style.Wrap(v)
+			}
+			break
+		}
+	case "raw":
+		v = rec.Raw
+	}
+	if v == "" {
+		return ""
+	}
+	if d.truncate > 0 {
+		v = style.Truncate(v, d.truncate, "…")
+	}
+	if d.styled {
+		v = d.style.Wrap(v)
+	}
+	switch d.align {
+	case alignLeft:
+		v = style.PadRight(v, d.width)
+	case alignRight:
+		v = style.PadLeft(v, d.width)
+	case alignCenter:
+		v = style.Center(v, d.width)
+	}
+	return v
+}
+
+func (r *TemplateRenderer) renderLevel(level, mode string) string {
+	if level == "" {
+		return ""
+	}
+	lvl := canonicalLevel(level)
+	switch mode {
+	case "", "badge":
+		return r.base.levelBadge(lvl)
+	case "label":
+		return r.base.levelLabel(lvl)
+	case "icon":
+		icon := "•"
+		if spec, ok := LookupLevel(lvl); ok && spec.Icon != "" {
+			icon = spec.Icon
+		}
+		return r.base.levelStyle(lvl).Wrap(icon)
+	}
+	return lvl
+}
+
+func parseTemplate(format string) ([]templateNode, error) {
+	stack := [][]templateNode{nil}
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			top := len(stack) - 1
+			stack[top] = append(stack[top], templateNode{text: text.String()})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		doubled := i+1 < len(format) && format[i+1] == c
+		switch c {
+		case '{':
+			if doubled {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("template: unclosed { at %d", i)
+			}
+			dir, err := parseTemplateDirective(format[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			top := len(stack) - 1
+			stack[top] = append(stack[top], templateNode{dir: dir})
+			i += end
+		case '}':
+			if !doubled {
+				return nil, fmt.Errorf("template: unmatched } at %d", i)
+			}
+			text.WriteByte(c)
+			i++
+		case '[':
+			if doubled {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			flush()
+			stack = append(stack, []templateNode{})
+		case ']':
+			if doubled {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("template: unmatched ] at %d", i)
+			}
+			flush()
+			section := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			top := len(stack) - 1
+			stack[top] = append(stack[top], templateNode{section: section})
+		default:
+			text.WriteByte(c)
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("template: unclosed [")
+	}
+	flush()
+	return stack[0], nil
+}
+
+func parseTemplateDirective(body string) (*templateDirective, error) {
+	parts := strings.Split(body, "|")
+	name, arg, _ := strings.Cut(parts[0], ":")
+	d := &templateDirective{name: strings.ToLower(strings.TrimSpace(name)), arg: arg}
+	switch d.name {
+	case "time", "level", "fields", "raw":
+	case "msg":
+		if strings.TrimSpace(arg) != "" {
+			if err := d.setStyle(arg); err != nil {
+				return nil, err
+			}
+			d.arg = ""
+		}
+	case "field":
+		d.arg = strings.TrimSpace(arg)
+		if d.arg == "" {
+			return nil, fmt.Errorf("template: {field} needs a key")
+		}
+	default:
+		return nil, fmt.Errorf("template: unknown directive %q", name)
+	}
+	for _, mod := range parts[1:] {
+		mod = strings.TrimSpace(mod)
+		if mod == "" {
+			continue
+		}
+		switch mod[0] {
+		case '<', '>', '^', '.':
+			n, err := strconv.Atoi(mod[1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("template: bad width %q in {%s}", mod, body)
+			}
+			if mod[0] == '.' {
+				d.truncate = n
+			} else {
+				d.align = templateAlign(mod[0])
+				d.width = n
+			}
+		default:
+			if err := d.setStyle(mod); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d *templateDirective) setStyle(spec string) error {
+	st, err := style.ParseStyle(spec)
+	if err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	d.style = st
+	d.styled = true
+	return nil
+}
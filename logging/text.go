@@ -0,0 +1,255 @@
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type textToken struct {
+	sep   string
+	key   string
+	value string
+	bare  bool
+}
+
+type headToken struct {
+	name string
+	tok  *textToken
+	pos  int
+}
+
+func ParseTextLogLine(line string) *LogRecord {
+	rec := &LogRecord{Raw: line, Fields: make([]RecordField, 0, 16)}
+	toks, lead, tail := scanTextTokens(line)
+	rec.lead, rec.tail = lead, tail
+	for i := range toks {
+		tok := &toks[i]
+		value, quoted := unquoteText(tok.value)
+		if tok.bare {
+			rec.Fields = append(rec.Fields, RecordField{
+				Value:  value,
+				Kind:   kindOf(value, quoted),
+				Quoted: quoted,
+				src:    tok,
+			})
+			continue
+		}
+		key, _ := unquoteText(tok.key)
+		switch {
+		case key == "time" && !rec.hasHead(key):
+			rec.Time = value
+		case key == "level" && !rec.hasHead(key):
+			rec.Level = foreignLevel(value)
+		case key == "msg" && !rec.hasHead(key):
+			rec.Message = value
+		default:
+			rec.Fields = append(rec.Fields, RecordField{
+				Key:     key,
+				Value:   value,
+				Kind:    kindOf(value, quoted),
+				Quoted:  quoted,
+				ShowKey: true,
+				src:     tok,
+			})
+			continue
+		}
+		rec.head = append(rec.head, headToken{name: key, tok: tok, pos: len(rec.Fields)})
+	}
+	return rec
+}
+
+func (rec *LogRecord) hasHead(name string) bool {
+	for _, h := range rec.head {
+		if h.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func scanTextTokens(s string) (toks []textToken, lead, tail string) {
+	toks = make([]textToken, 0, 16)
+	i := 0
+	for i < len(s) {
+		start := i
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		sep := s[start:i]
+		if i == len(s) {
+			tail = sep
+			break
+		}
+		if len(toks) == 0 {
+			lead, sep = sep, ""
+		}
+		begin, eq := i, -1
+		inQuotes, escaped := false, false
+	scan:
+		for ; i < len(s); i++ {
+			c := s[i]
+			switch {
+			case inQuotes && escaped:
+				escaped = false
+			case inQuotes && c == '\\':
+				escaped = true
+			case inQuotes:
+				inQuotes = c != '"'
+			case c == '"':
+				inQuotes = true
+			case c == '=' && eq < 0:
+				eq = i
+			case c == ' ':
+				break scan
+			}
+		}
+		tok := textToken{sep: sep}
+		if eq <= begin {
+			tok.value, tok.bare = s[begin:i], true
+		} else {
+			tok.key, tok.value = s[begin:eq], s[eq+1:i]
+		}
+		toks = append(toks, tok)
+	}
+	return toks, lead, tail
+}
+
+func unquoteText(raw string) (string, bool) {
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		if uq, err := strconv.Unquote(raw); err == nil {
+			return uq, true
+		}
+	}
+	return raw, false
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == utf8.RuneError || r == '"' || r == '=' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteText(s string) string {
+	if needsQuoting(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func quoteTextValue(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s
+	}
+	return quoteText(s)
+}
+
+func (f RecordField) textValue() string {
+	if f.src != nil {
+		if v, q := unquoteText(f.src.value); v == f.Value && q == f.Quoted {
+			return f.src.value
+		}
+	}
+	if f.Quoted {
+		return strconv.Quote(f.Value)
+	}
+	return quoteText(f.Value)
+}
+
+func (f RecordField) displayValue() string {
+	if f.ValueOut != "" {
+		return f.ValueOut
+	}
+	return f.textValue()
+}
+
+func (f RecordField) text() string {
+	value := f.textValue()
+	if f.ValueOut != "" {
+		value = quoteTextValue(f.ValueOut)
+	}
+	if f.Key == "" && (f.src == nil || f.src.bare) {
+		return value
+	}
+	key := quoteText(f.Key)
+	if f.src != nil && !f.src.bare {
+		if k, _ := unquoteText(f.src.key); k == f.Key {
+			key = f.src.key
+		}
+	}
+	return key + "=" + value
+}
+
+func (rec *LogRecord) headText(h headToken) (string, bool) {
+	raw, _ := unquoteText(h.tok.value)
+	var cur string
+	unchanged := false
+	switch h.name {
+	case "time":
+		cur, unchanged = rec.Time, raw == rec.Time
+	case "level":
+		cur, unchanged = strings.ToUpper(rec.Level), foreignLevel(raw) == rec.Level
+	case "msg":
+		cur, unchanged = rec.Message, raw == rec.Message
+	}
+	switch {
+	case unchanged:
+		return h.tok.key + "=" + h.tok.value, true
+	case cur == "" && h.name != "msg":
+		return "", false
+	}
+	return h.tok.key + "=" + quoteText(cur), true
+}
+
+func renderTextRecord(rec *LogRecord) string {
+	if rec == nil {
+		return ""
+	}
+	var b strings.Builder
+	n := 0
+	emit := func(tok *textToken, text string) {
+		switch {
+		case n == 0:
+			b.WriteString(rec.lead)
+		case tok != nil && tok.sep != "":
+			b.WriteString(tok.sep)
+		default:
+			b.WriteByte(' ')
+		}
+		b.WriteString(text)
+		n++
+	}
+	for _, h := range [...]struct{ name, value string }{
+		{"time", rec.Time},
+		{"level", strings.ToUpper(rec.Level)},
+		{"msg", rec.Message},
+	} {
+		if h.value != "" && !rec.hasHead(h.name) {
+			emit(nil, h.name+"="+quoteText(h.value))
+		}
+	}
+	hi := 0
+	emitHead := func(h headToken) {
+		if text, ok := rec.headText(h); ok {
+			emit(h.tok, text)
+		}
+	}
+	for i, f := range rec.Fields {
+		for ; hi < len(rec.head) && rec.head[hi].pos <= i; hi++ {
+			emitHead(rec.head[hi])
+		}
+		emit(f.src, f.text())
+	}
+	for ; hi < len(rec.head); hi++ {
+		emitHead(rec.head[hi])
+	}
+	b.WriteString(rec.tail)
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+type TimeMode int
+
+const (
+	TimeAbsolute TimeMode = iota
+	TimeElideRepeated
+	TimeDelta
+)
+
+type timeFormatter struct {
+	layout string
+	loc    *time.Location
+	mode   TimeMode
+
+	mu      sync.Mutex
+	prev    time.Time
+	hasPrev bool
+}
+
+func newTimeFormatter(p Profile) *timeFormatter {
+	return &timeFormatter{layout: p.TimeLayout, loc: p.TimeLocation, mode: p.TimeMode}
+}
+
+func (f *timeFormatter) format(raw, layout string) string {
+	if raw == "" {
+		return ""
+	}
+	if layout == "" {
+		layout = f.layout
+	}
+	if layout == "" && f.loc == nil && f.mode == TimeAbsolute {
+		return raw
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return raw
+	}
+	if f.loc != nil {
+		t = t.In(f.loc)
+	}
+	out := raw
+	if layout != "" || f.loc != nil {
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		out = t.Format(layout)
+	}
+
+	f.mu.Lock()
+	prev, hasPrev := f.prev, f.hasPrev
+	f.prev, f.hasPrev = t, true
+	f.mu.Unlock()
+	if !hasPrev {
+		return out
+	}
+	switch f.mode {
+	case TimeElideRepeated:
+		if t.Truncate(time.Second).Equal(prev.Truncate(time.Second)) {
+			return strings.Repeat(" ", style.Width(out))
+		}
+	case TimeDelta:
+		return "+" + formatDelta(t.Sub(prev))
+	}
+	return out
+}
+
+func formatDelta(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Millisecond:
+		return strconv.FormatInt(d.Microseconds(), 10) + "µs"
+	case d < time.Second:
+		return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+	case d < time.Minute:
+		return strconv.FormatFloat(d.Seconds(), 'f', 3, 64) + "s"
+	}
+	return d.Round(time.Second).String()
+}
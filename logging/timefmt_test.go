@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+func TestTimeFormatter(t *testing.T) {
+	utc8 := time.FixedZone("UTC+8", 8*3600)
+	tests := []struct {
+		name    string
+		profile Profile
+		layout  string
+		in      []string
+		want    []string
+	}{
+		{
+			name: "absolute keeps raw",
+			in:   []string{"2026-01-02T15:04:05.123Z", "not a time"},
+			want: []string{"2026-01-02T15:04:05.123Z", "not a time"},
+		},
+		{
+			name:    "layout",
+			profile: Profile{TimeLayout: time.TimeOnly},
+			in:      []string{"2026-01-02T15:04:05.123Z"},
+			want:    []string{"15:04:05"},
+		},
+		{
+			name:    "directive layout wins",
+			profile: Profile{TimeLayout: time.TimeOnly},
+			layout:  time.DateOnly,
+			in:      []string{"2026-01-02T15:04:05.123Z"},
+			want:    []string{"2026-01-02"},
+		},
+		{
+			name:    "zone conversion",
+			profile: Profile{TimeLayout: time.DateTime, TimeLocation: utc8},
+			in:      []string{"2026-01-02T20:04:05Z"},
+			want:    []string{"2026-01-03 04:04:05"},
+		},
+		{
+			name:    "elide repeated second",
+			profile: Profile{TimeLayout: time.TimeOnly, TimeMode: TimeElideRepeated},
+			in:      []string{"2026-01-02T15:04:05.100Z", "2026-01-02T15:04:05.900Z", "2026-01-02T15:04:06.000Z"},
+			want:    []string{"15:04:05", "        ", "15:04:06"},
+		},
+		{
+			name:    "elide pads by display width",
+			profile: Profile{TimeLayout: "15時04分05秒", TimeMode: TimeElideRepeated},
+			in:      []string{"2026-01-02T15:04:05.100Z", "2026-01-02T15:04:05.200Z"},
+			want:    []string{"15時04分05秒", "            "},
+		},
+		{
+			name:    "delta",
+			profile: Profile{TimeMode: TimeDelta},
+			in:      []string{"2026-01-02T15:04:05.000Z", "2026-01-02T15:04:05.120Z", "2026-01-02T15:04:07.500Z", "2026-01-02T15:06:07.500Z"},
+			want:    []string{"2026-01-02T15:04:05.000Z", "+120ms", "+2.380s", "+2m0s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTimeFormatter(tt.profile)
+			for i, in := range tt.in {
+				if got := f.format(in, tt.layout); got != tt.want[i] {
+					t.Fatalf("line %d: format(%q) = %q, want %q", i, in, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatDelta(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-time.Second, "0µs"},
+		{750 * time.Microsecond, "750µs"},
+		{42 * time.Millisecond, "42ms"},
+		{1500 * time.Millisecond, "1.500s"},
+		{90 * time.Minute, "1h30m0s"},
+	}
+	for _, tt := range tests {
+		if got := formatDelta(tt.d); got != tt.want {
+			t.Errorf("formatDelta(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestForkedPipelineKeepsOwnDelta(t *testing.T) {
+	p := NewPipeline(style.DefaultTheme(), Profile{TimeMode: TimeDelta}, nil, nil, nil, nil)
+	live := p.Fork()
+	history := p.Fork()
+	render := func(pl *Pipeline, ts string) string {
+		return pl.Render(&LogRecord{Time: ts})
+	}
+	_ = render(live, "2026-01-02T15:04:05Z")
+	_ = render(history, "2026-01-01T00:00:00Z")
+	got := style.StripANSI(render(live, "2026-01-02T15:04:06Z"))
+	if got != "+1.000s" {
+		t.Fatalf("live delta = %q, want +1.000s", got)
+	}
+}
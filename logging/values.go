@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VexoraDevelopment/consolex/style"
+)
+
+type ValueKind int
+
+const (
+	KindText ValueKind = iota
+	KindString
+	KindNumber
+	KindDuration
+	KindBool
+	KindNil
+	KindAddress
+	KindUUID
+	KindPointer
+	KindPath
+	KindURL
+)
+
+var valueKindNames = [...]string{
+	KindText:     "text",
+	KindString:   "string",
+	KindNumber:   "number",
+	KindDuration: "duration",
+	KindBool:     "bool",
+	KindNil:      "nil",
+	KindAddress:  "address",
+	KindUUID:     "uuid",
+	KindPointer:  "pointer",
+	KindPath:     "path",
+	KindURL:      "url",
+}
+
+func (k ValueKind) String() string {
+	if k >= 0 && int(k) < len(valueKindNames) {
+		return valueKindNames[k]
+	}
+	return "text"
+}
+
+var (
+	uuidPattern    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	pointerPattern = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	windowsPath    = regexp.MustCompile(`^[A-Za-z]:[\\/]`)
+)
+
+func ClassifyValue(value string) ValueKind {
+	return kindOf(unquoteText(value))
+}
+
+func kindOf(value string, quoted bool) ValueKind {
+	if kind := classifyText(value); kind != KindText {
+		return kind
+	}
+	if quoted {
+		return KindString
+	}
+	return KindText
+}
+
+func classifyText(v string) ValueKind {
+	switch v {
+	case "":
+		return KindText
+	case "<nil>", "nil", "null":
+		return KindNil
+	case "true", "false":
+		return KindBool
+	}
+	switch {
+	case pointerPattern.MatchString(v):
+		return KindPointer
+	case uuidPattern.MatchString(v):
+		return KindUUID
+	case isNumber(v):
+		return KindNumber
+	case isDuration(v):
+		return KindDuration
+	case isURL(v):
+		return KindURL
+	case isAddress(v):
+		return KindAddress
+	case isPath(v):
+		return KindPath
+	}
+	return KindText
+}
+
+func isNumber(v string) bool {
+	c := v[0]
+	if c == '-' || c == '+' {
+		if len(v) == 1 {
+			return false
+		}
+		c = v[1]
+	}
+	if c < '0' || c > '9' {
+		return false
+	}
+	_, err := strconv.ParseFloat(v, 64)
+	return err == nil
+}
+
+func isDuration(v string) bool {
+	if strings.IndexAny(v, "0123456789") < 0 {
+		return false
+	}
+	_, err := time.ParseDuration(v)
+	return err == nil
+}
+
+func isURL(v string) bool {
+	if !strings.Contains(v, "://") {
+		return false
+	}
+	u, err := url.Parse(v)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func isAddress(v string) bool {
+	if net.ParseIP(v) != nil {
+		return true
+	}
+	host, port, err := net.SplitHostPort(v)
+	if err != nil {
+		return false
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return false
+	}
+	return host == "" || host == "::" || net.ParseIP(host) != nil || host == "localhost"
+}
+
+func isPath(v string) bool {
+	if strings.ContainsAny(v, " \t") {
+		return false
+	}
+	return strings.HasPrefix(v, "/") || strings.HasPrefix(v, "./") || strings.HasPrefix(v, "../") ||
+		strings.HasPrefix(v, "~/") || windowsPath.MatchString(v)
+}
+
+type valueHighlighter struct {
+	theme style.Theme
+}
+
+func NewValueHighlighter(theme style.Theme) Processor {
+	return valueHighlighter{theme: theme}
+}
+
+func (p valueHighlighter) Process(rec *LogRecord) {
+	for i := range rec.Fields {
+		f := &rec.Fields[i]
+		if f.Styled {
+			continue
+		}
+		kind := f.Kind
+		if f.ValueOut != "" {
+			kind = ClassifyValue(f.ValueOut)
+		}
+		if kind == KindText {
+			continue
+		}
+		if st, ok := p.theme.Lookup("value." + kind.String()); ok {
+			f.Style = st
+			f.Styled = true
+		}
+	}
+}
@@ -14,13 +14,26 @@ func DefaultPalette() Palette { return NewPalette(DefaultTheme()) }
 func NordPalette() Palette    { return NewPalette(NordTheme()) }
 func SunsetPalette() Palette  { return NewPalette(SunsetTheme()) }
 
+func HighContrastPalette() Palette { return NewPalette(HighContrastTheme()) }
+func ColorBlindPalette() Palette   { return NewPalette(ColorBlindTheme()) }
+
 func (p Palette) Success(v ...any) string { return p.Theme.Info.Bold().Sprint(v...) }
 func (p Palette) Info(v ...any) string    { return p.Theme.Info.Sprint(v...) }
 func (p Palette) Warn(v ...any) string    { return p.Theme.Warn.Bold().Sprint(v...) }
 func (p Palette) Error(v ...any) string   { return p.Theme.Error.Bold().Sprint(v...) }
 func (p Palette) Debug(v ...any) string   { return p.Theme.Debug.Sprint(v...) }
-func (p Palette) Muted(v ...any) string   { return p.Theme.TimeKey.Sprint(v...) }
+func (p Palette) Muted(v ...any) string   { return p.Theme.Style("muted").Sprint(v...) }
+
+func (p Palette) Token(name string, v ...any) string { return p.Theme.Style(name).Sprint(v...) }
 
 func (p Palette) KV(key string, value any) string {
-	return p.Theme.MsgKey.Wrap(key) + "=" + p.Theme.TimeValue.Wrap(fmt.Sprint(value))
+	keyStyle, ok := p.Theme.Override("field.key")
+	if !ok {
+		keyStyle = p.Theme.MsgKey
+	}
+	valueStyle, ok := p.Theme.Override("value")
+	if !ok {
+		valueStyle = p.Theme.TimeValue
+	}
+	return keyStyle.Wrap(key) + "=" + valueStyle.Wrap(fmt.Sprint(value))
 }
@@ -0,0 +1,22 @@
+package consolex
+
+import "testing"
+
+func TestPaletteKV(t *testing.T) {
+	base := DefaultTheme()
+	overridden := DefaultTheme()
+	overridden.Tokens = map[string]Chalk{"field.key": New().Red(), "value": New().Green()}
+	tests := []struct {
+		name  string
+		theme Theme
+		want  string
+	}{
+		{"baseline colours", base, base.MsgKey.Wrap("k") + "=" + base.TimeValue.Wrap("1")},
+		{"explicit tokens", overridden, New().Red().Wrap("k") + "=" + New().Green().Wrap("1")},
+	}
+	for _, tt := range tests {
+		if got := NewPalette(tt.theme).KV("k", 1); got != tt.want {
+			t.Errorf("%s: KV = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -95,7 +95,11 @@ func (c Chalk) Wrap(text string) string {
 	if !c.enabled || len(c.codes) == 0 || text == "" {
 		return text
 	}
-	return "\x1b[" + strings.Join(c.codes, ";") + "m" + text + "\x1b[0m"
+	open := "\x1b[" + strings.Join(c.codes, ";") + "m"
+	if strings.Contains(text, ansiReset) {
+		text = strings.ReplaceAll(text, ansiReset, ansiReset+open)
+	}
+	return open + text + ansiReset
 }
 
 func (c Chalk) Sprint(v ...any) string {
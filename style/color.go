@@ -0,0 +1,201 @@
+package style
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type rgb struct {
+	r, g, b uint8
+}
+
+var ansiColors = [16]rgb{
+	{0, 0, 0}, {205, 49, 49}, {13, 188, 121}, {229, 229, 16},
+	{36, 114, 200}, {188, 63, 188}, {17, 168, 205}, {229, 229, 229},
+	{102, 102, 102}, {241, 76, 76}, {35, 209, 139}, {245, 245, 67},
+	{59, 142, 234}, {214, 112, 214}, {41, 184, 219}, {255, 255, 255},
+}
+
+func parseRGB(hex string) (rgb, bool) {
+	r, g, b, ok := parseHexColor(hex)
+	return rgb{r, g, b}, ok
+}
+
+func (c rgb) hex() string {
+	return fmt.Sprintf("#%02X%02X%02X", c.r, c.g, c.b)
+}
+
+func (c rgb) luminance() float64 {
+	lin := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*lin(c.r) + 0.7152*lin(c.g) + 0.0722*lin(c.b)
+}
+
+func contrast(a, b rgb) float64 {
+	la, lb := a.luminance(), b.luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+func ContrastRatio(fg, bg string) (float64, error) {
+	a, ok := parseRGB(fg)
+	if !ok {
+		return 0, fmt.Errorf("bad colour %q", fg)
+	}
+	b, ok := parseRGB(bg)
+	if !ok {
+		return 0, fmt.Errorf("bad colour %q", bg)
+	}
+	return contrast(a, b), nil
+}
+
+func (c rgb) hsl() (h, s, l float64) {
+	r, g, b := float64(c.r)/255, float64(c.g)/255, float64(c.b)/255
+	maxV := math.Max(r, math.Max(g, b))
+	minV := math.Min(r, math.Min(g, b))
+	l = (maxV + minV) / 2
+	if maxV == minV {
+		return 0, 0, l
+	}
+	d := maxV - minV
+	if l > 0.5 {
+		s = d / (2 - maxV - minV)
+	} else {
+		s = d / (maxV + minV)
+	}
+	switch maxV {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+func fromHSL(h, s, l float64) rgb {
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 360
+	s = clamp01(s)
+	l = clamp01(l)
+	if s == 0 {
+		v := uint8(math.Round(l * 255))
+		return rgb{v, v, v}
+	}
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	conv := func(t float64) uint8 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 0.5:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return uint8(math.Round(clamp01(v) * 255))
+	}
+	return rgb{conv(h + 1.0/3), conv(h), conv(h - 1.0/3)}
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func ensureContrast(c, against rgb, minRatio float64) rgb {
+	if contrast(c, against) >= minRatio {
+		return c
+	}
+	h, s, l := c.hsl()
+	step := 0.02
+	if against.luminance() > 0.5 {
+		step = -step
+	}
+	for i := 0; i < 50; i++ {
+		l = clamp01(l + step)
+		c = fromHSL(h, s, l)
+		if contrast(c, against) >= minRatio || l == 0 || l == 1 {
+			break
+		}
+	}
+	return c
+}
+
+func (c Chalk) colors() (fg, bg rgb, hasFg, hasBg bool) {
+	for _, code := range c.codes {
+		if parts := strings.Split(code, ";"); len(parts) == 5 && parts[1] == "2" {
+			var v [3]uint8
+			for i := range v {
+				n, _ := strconv.ParseUint(parts[i+2], 10, 8)
+				v[i] = uint8(n)
+			}
+			switch parts[0] {
+			case "38":
+				fg, hasFg = rgb{v[0], v[1], v[2]}, true
+			case "48":
+				bg, hasBg = rgb{v[0], v[1], v[2]}, true
+			}
+			continue
+		}
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			continue
+		}
+		switch {
+		case n >= 30 && n <= 37:
+			fg, hasFg = ansiColors[n-30], true
+		case n >= 90 && n <= 97:
+			fg, hasFg = ansiColors[n-90+8], true
+		case n >= 40 && n <= 47:
+			bg, hasBg = ansiColors[n-40], true
+		case n >= 100 && n <= 107:
+			bg, hasBg = ansiColors[n-100+8], true
+		}
+	}
+	return fg, bg, hasFg, hasBg
+}
+
+func (c Chalk) withForeground(fg rgb) Chalk {
+	out := Chalk{enabled: c.enabled, codes: make([]string, 0, len(c.codes)+1)}
+	for _, code := range c.codes {
+		if isForegroundCode(code) {
+			continue
+		}
+		out.codes = append(out.codes, code)
+	}
+	return out.RGB(fg.r, fg.g, fg.b)
+}
+
+func isForegroundCode(code string) bool {
+	if strings.HasPrefix(code, "38;") {
+		return true
+	}
+	n, err := strconv.Atoi(code)
+	return err == nil && (n >= 30 && n <= 37 || n >= 90 && n <= 97)
+}
@@ -0,0 +1,265 @@
+package style
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultMinContrast = 4.5
+
+var (
+	darkBackground  = rgb{0x1C, 0x1C, 0x1C}
+	lightBackground = rgb{0xFA, 0xFA, 0xFA}
+	badgeDark       = rgb{0x11, 0x11, 0x11}
+	badgeLight      = rgb{0xFF, 0xFF, 0xFF}
+)
+
+type ThemeSpec struct {
+	Name        string
+	Accent      string
+	Secondary   string
+	Debug       string
+	Info        string
+	Warn        string
+	Error       string
+	Variant     Variant
+	MinContrast float64
+}
+
+type ContrastIssue struct {
+	Token string
+	Ratio float64
+	Min   float64
+}
+
+func (i ContrastIssue) String() string {
+	return fmt.Sprintf("%s: contrast %.2f < %.2f", i.Token, i.Ratio, i.Min)
+}
+
+func GenerateTheme(spec ThemeSpec) (Theme, error) {
+	accent, ok := parseRGB(spec.Accent)
+	if !ok {
+		return Theme{}, fmt.Errorf("generate theme: bad accent colour %q", spec.Accent)
+	}
+	h, s, _ := accent.hsl()
+	secondary := fromHSL(h+150, s, 0.6)
+	if spec.Secondary != "" {
+		if secondary, ok = parseRGB(spec.Secondary); !ok {
+			return Theme{}, fmt.Errorf("generate theme: bad secondary colour %q", spec.Secondary)
+		}
+	}
+	sh, ss, _ := secondary.hsl()
+	badges := map[string]rgb{
+		"debug": fromHSL(sh, ss, 0.65),
+		"info":  fromHSL(h, s, 0.6),
+		"warn":  fromHSL(45, 0.95, 0.6),
+		"error": fromHSL(355, 0.7, 0.55),
+	}
+	for name, hex := range map[string]string{"debug": spec.Debug, "info": spec.Info, "warn": spec.Warn, "error": spec.Error} {
+		if hex == "" {
+			continue
+		}
+		c, ok := parseRGB(hex)
+		if !ok {
+			return Theme{}, fmt.Errorf("generate theme: bad %s colour %q", name, hex)
+		}
+		badges[name] = c
+	}
+	minRatio := spec.MinContrast
+	if minRatio <= 0 {
+		minRatio = defaultMinContrast
+	}
+	bg, text := darkBackground, 0.92
+	if spec.Variant == VariantLight {
+		bg, text = lightBackground, 0.12
+	}
+	eh, es, _ := badges["error"].hsl()
+	fg := func(c rgb, ratio float64) Chalk {
+		return New().Hex(ensureContrast(c, bg, ratio).hex())
+	}
+	return Theme{
+		Name:      normalizeThemeName(spec.Name),
+		Variant:   spec.Variant,
+		TimeKey:   fg(fromHSL(h, s*0.25, 0.55), minRatio*2/3),
+		TimeValue: fg(accent, minRatio),
+		MsgKey:    fg(fromHSL(h, 0.15, text), minRatio).Bold(),
+		Debug:     badge(badges["debug"], minRatio),
+		Info:      badge(badges["info"], minRatio),
+		Warn:      badge(badges["warn"], minRatio),
+		Error:     badge(badges["error"], minRatio),
+		ErrKey:    badge(fromHSL(eh+20, es, 0.6), minRatio),
+		PlayerKey: fg(secondary, minRatio),
+		WorldKey:  fg(fromHSL(h+60, s, 0.6), minRatio),
+	}, nil
+}
+
+func GenerateVariants(spec ThemeSpec) (dark, light Theme, err error) {
+	spec.Variant = VariantDark
+	if dark, err = GenerateTheme(spec); err != nil {
+		return Theme{}, Theme{}, err
+	}
+	spec.Variant = VariantLight
+	if spec.Name != "" {
+		spec.Name += "-light"
+	}
+	if light, err = GenerateTheme(spec); err != nil {
+		return Theme{}, Theme{}, err
+	}
+	return dark, light, nil
+}
+
+func badge(bg rgb, minRatio float64) Chalk {
+	fg := badgeDark
+	if contrast(badgeLight, bg) > contrast(badgeDark, bg) {
+		fg = badgeLight
+	}
+	bg = ensureContrast(bg, fg, minRatio)
+	return New().Hex(fg.hex()).BgHex(bg.hex()).Bold()
+}
+
+func CheckContrast(t Theme, minRatio float64) []ContrastIssue {
+	if minRatio <= 0 {
+		minRatio = defaultMinContrast
+	}
+	var issues []ContrastIssue
+	for _, token := range []string{"level.debug", "level.info", "level.warn", "level.error", "attr.err"} {
+		fg, bg, hasFg, hasBg := t.Style(token).colors()
+		if !hasBg {
+			continue
+		}
+		if !hasFg {
+			fg = badgeLight
+			if t.Variant == VariantLight {
+				fg = badgeDark
+			}
+		}
+		if ratio := contrast(fg, bg); ratio < minRatio {
+			issues = append(issues, ContrastIssue{Token: token, Ratio: ratio, Min: minRatio})
+		}
+	}
+	return issues
+}
+
+func mustGenerate(spec ThemeSpec) Theme {
+	t, err := GenerateTheme(spec)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func HighContrastTheme() Theme {
+	return mustGenerate(ThemeSpec{Name: "high-contrast", Accent: "#00B7FF", Secondary: "#FFB000", MinContrast: 7})
+}
+
+func HighContrastLightTheme() Theme {
+	return mustGenerate(ThemeSpec{Name: "high-contrast-light", Accent: "#005FCC", Secondary: "#A64B00", Variant: VariantLight, MinContrast: 7})
+}
+
+func ColorBlindTheme() Theme {
+	return mustGenerate(colorBlindSpec("colorblind", VariantDark))
+}
+
+func ColorBlindLightTheme() Theme {
+	return mustGenerate(colorBlindSpec("colorblind-light", VariantLight))
+}
+
+func colorBlindSpec(name string, variant Variant) ThemeSpec {
+	return ThemeSpec{
+		Name:      name,
+		Accent:    "#56B4E9",
+		Secondary: "#CC79A7",
+		Debug:     "#009E73",
+		Info:      "#56B4E9",
+		Warn:      "#F0E442",
+		Error:     "#D55E00",
+		Variant:   variant,
+	}
+}
+
+func (t Theme) ForVariant(v Variant) Theme {
+	if t.Variant == v {
+		return t
+	}
+	base := strings.TrimSuffix(t.Name, "-light")
+	name := base
+	if v == VariantLight {
+		name = base + "-light"
+	}
+	if t.Name != "" {
+		if other, ok := LookupTheme(name); ok && other.Variant == v {
+			return other
+		}
+	}
+	return AdaptTheme(t, v)
+}
+
+func AdaptTheme(t Theme, v Variant) Theme {
+	bg := darkBackground
+	if v == VariantLight {
+		bg = lightBackground
+	}
+	adapt := func(c Chalk, minRatio float64) Chalk {
+		fg, _, hasFg, hasBg := c.colors()
+		if !hasFg || hasBg {
+			return c
+		}
+		return c.withForeground(ensureContrast(fg, bg, minRatio))
+	}
+	out := t
+	if t.Name != "" {
+		out.Name = strings.TrimSuffix(t.Name, "-light")
+		if v == VariantLight {
+			out.Name += "-light"
+		}
+	}
+	out.Variant = v
+	for _, s := range out.slots() {
+		minRatio := defaultMinContrast
+		if s.key == "time_key" {
+			minRatio = 3
+		}
+		*s.chalk = adapt(*s.chalk, minRatio)
+	}
+	if len(t.Tokens) > 0 {
+		out.Tokens = make(map[string]Chalk, len(t.Tokens))
+		for name, c := range t.Tokens {
+			out.Tokens[name] = adapt(c, defaultMinContrast)
+		}
+	}
+	return out
+}
+
+func DistinctColors(t Theme, n int) []Chalk {
+	if n <= 0 {
+		return nil
+	}
+	bg, l := darkBackground, 0.68
+	if t.Variant == VariantLight {
+		bg, l = lightBackground, 0.38
+	}
+	const goldenAngle = 137.508
+	out := make([]Chalk, 0, n)
+	for i := 0; i < n; i++ {
+		h := 20 + float64(i)*goldenAngle
+		s := 0.75
+		if i%2 == 1 {
+			s = 0.55
+		}
+		c := ensureContrast(fromHSL(h, s, l), bg, defaultMinContrast)
+		out = append(out, New().Hex(c.hex()))
+	}
+	return out
+}
+
+func DistinctBadges(t Theme, n int) []Chalk {
+	if n <= 0 {
+		return nil
+	}
+	const goldenAngle = 137.508
+	out := make([]Chalk, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, badge(fromHSL(20+float64(i)*goldenAngle, 0.6, 0.6), defaultMinContrast))
+	}
+	return out
+}
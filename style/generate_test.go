@@ -0,0 +1,153 @@
+package style
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHSLRoundTrip(t *testing.T) {
+	tests := []struct {
+		hex     string
+		h, s, l float64
+	}{
+		{"#000000", 0, 0, 0},
+		{"#FFFFFF", 0, 0, 1},
+		{"#FF0000", 0, 1, 0.5},
+		{"#00FF00", 120, 1, 0.5},
+		{"#0000FF", 240, 1, 0.5},
+		{"#808080", 0, 0, 0.502},
+		{"#56B4E9", 201.8, 0.771, 0.625},
+	}
+	for _, tt := range tests {
+		c, ok := parseRGB(tt.hex)
+		if !ok {
+			t.Fatalf("parseRGB(%q) failed", tt.hex)
+		}
+		h, s, l := c.hsl()
+		if math.Abs(h-tt.h) > 0.5 || math.Abs(s-tt.s) > 0.005 || math.Abs(l-tt.l) > 0.005 {
+			t.Errorf("%s.hsl() = %.1f %.3f %.3f, want %.1f %.3f %.3f", tt.hex, h, s, l, tt.h, tt.s, tt.l)
+		}
+		if got := fromHSL(h, s, l).hex(); got != tt.hex {
+			t.Errorf("fromHSL(%s.hsl()) = %s", tt.hex, got)
+		}
+	}
+	if got := fromHSL(-120, 1, 0.5).hex(); got != "#0000FF" {
+		t.Errorf("fromHSL(-120) = %s, want hue to wrap", got)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		fg, bg string
+		want   float64
+	}{
+		{"#000000", "#FFFFFF", 21},
+		{"#FFFFFF", "#000000", 21},
+		{"#777777", "#777777", 1},
+		{"#767676", "#FFFFFF", 4.54},
+		{"#FFFFFF", "#CD3131", 5.15},
+	}
+	for _, tt := range tests {
+		got, err := ContrastRatio(tt.fg, tt.bg)
+		if err != nil || math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("ContrastRatio(%s, %s) = %.2f, %v; want %.2f", tt.fg, tt.bg, got, err, tt.want)
+		}
+	}
+	if _, err := ContrastRatio("red", "#000000"); err == nil {
+		t.Error("ContrastRatio accepted a colour name")
+	}
+	dark, _ := parseRGB("#202020")
+	for _, against := range []rgb{darkBackground, lightBackground} {
+		if got := contrast(ensureContrast(dark, against, 4.5), against); got < 4.5 {
+			t.Errorf("ensureContrast against %s reached only %.2f", against.hex(), got)
+		}
+	}
+}
+
+func TestPresetsPassContrastCheck(t *testing.T) {
+	for _, name := range ThemeNames() {
+		theme, _ := LookupTheme(name)
+		if issues := CheckContrast(theme, 0); len(issues) > 0 {
+			t.Errorf("%s: %v", name, issues)
+		}
+	}
+}
+
+func TestGenerateTheme(t *testing.T) {
+	if _, err := GenerateTheme(ThemeSpec{Accent: "teal"}); err == nil {
+		t.Error("GenerateTheme accepted a bad accent")
+	}
+	if _, err := GenerateTheme(ThemeSpec{Accent: "#00AA88", Error: "#12"}); err == nil {
+		t.Error("GenerateTheme accepted a bad error colour")
+	}
+	for _, accent := range []string{"#00AA88", "#FF00FF", "#202020", "#F0F0F0", "#FFD700"} {
+		dark, light, err := GenerateVariants(ThemeSpec{Name: "gen", Accent: accent})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dark.Name != "gen" || dark.Variant != VariantDark || light.Name != "gen-light" || light.Variant != VariantLight {
+			t.Errorf("%s: variants = %s/%v, %s/%v", accent, dark.Name, dark.Variant, light.Name, light.Variant)
+		}
+		for _, theme := range []Theme{dark, light} {
+			if issues := CheckContrast(theme, 0); len(issues) > 0 {
+				t.Errorf("%s %s: %v", accent, theme.Name, issues)
+			}
+			checkForegrounds(t, accent, theme)
+		}
+	}
+}
+
+func TestVariantRoundTrip(t *testing.T) {
+	themes := []Theme{DefaultTheme(), NordTheme(), SunsetTheme(), ColorBlindTheme(), HighContrastTheme()}
+	adapted := DefaultTheme()
+	adapted.Name = "custom"
+	adapted.Tokens = map[string]Chalk{"attr.conn_id": New().Hex("#FFFF80")}
+	themes = append(themes, adapted)
+	for _, theme := range themes {
+		light := theme.ForVariant(VariantLight)
+		if light.Variant != VariantLight || light.Name != theme.Name+"-light" {
+			t.Errorf("%s: light variant is %s/%v", theme.Name, light.Name, light.Variant)
+		}
+		checkForegrounds(t, theme.Name, light)
+		back := light.ForVariant(VariantDark)
+		if back.Variant != VariantDark || back.Name != theme.Name {
+			t.Errorf("%s: dark round trip is %s/%v", theme.Name, back.Name, back.Variant)
+		}
+		if _, registered := LookupTheme(theme.Name); registered {
+			if back.WorldKey.Wrap("x") != theme.WorldKey.Wrap("x") || back.Error.Wrap("x") != theme.Error.Wrap("x") {
+				t.Errorf("%s: dark round trip did not return the registered theme", theme.Name)
+			}
+		} else {
+			checkForegrounds(t, theme.Name, back)
+		}
+		if again := back.ForVariant(VariantDark); again.Name != back.Name || again.Error.Wrap("x") != back.Error.Wrap("x") {
+			t.Errorf("%s: ForVariant of the current variant changed the theme", theme.Name)
+		}
+	}
+}
+
+func checkForegrounds(t *testing.T, label string, theme Theme) {
+	t.Helper()
+	bg := darkBackground
+	if theme.Variant == VariantLight {
+		bg = lightBackground
+	}
+	for _, s := range theme.slots() {
+		fg, _, hasFg, hasBg := s.chalk.colors()
+		if !hasFg || hasBg {
+			continue
+		}
+		min := defaultMinContrast
+		if s.key == "time_key" {
+			min = 3
+		}
+		if got := contrast(fg, bg); got < min-0.01 {
+			t.Errorf("%s %s: %s %.2f < %.2f", label, theme.Name, s.key, got, min)
+		}
+	}
+	for name, c := range theme.Tokens {
+		if fg, _, hasFg, hasBg := c.colors(); hasFg && !hasBg && contrast(fg, bg) < defaultMinContrast-0.01 {
+			t.Errorf("%s %s: token %s %.2f", label, theme.Name, name, contrast(fg, bg))
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package style
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var specColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var specModifiers = map[string]int{
+	"bold":          1,
+	"dim":           2,
+	"italic":        3,
+	"underline":     4,
+	"inverse":       7,
+	"strikethrough": 9,
+}
+
+func ParseStyle(spec string) (Chalk, error) {
+	c := New()
+	for _, tok := range strings.Fields(spec) {
+		word := strings.ToLower(tok)
+		bg := false
+		if strings.HasPrefix(word, "bg:") {
+			bg = true
+			word = word[3:]
+		}
+		if strings.HasPrefix(word, "sgr:") {
+			code := word[4:]
+			if code == "" || strings.Trim(code, "0123456789;") != "" {
+				return Chalk{}, fmt.Errorf("style %q: bad sgr code %q", spec, tok)
+			}
+			c = c.cloneWith(code)
+			continue
+		}
+		if !bg {
+			if v, ok := specModifiers[word]; ok {
+				c = c.code(v)
+				continue
+			}
+		}
+		if strings.HasPrefix(word, "#") {
+			r, g, b, ok := parseHexColor(word)
+			if !ok {
+				return Chalk{}, fmt.Errorf("style %q: bad hex colour %q", spec, tok)
+			}
+			if bg {
+				c = c.BgRGB(r, g, b)
+			} else {
+				c = c.RGB(r, g, b)
+			}
+			continue
+		}
+		v, ok := namedColorCode(word)
+		if !ok {
+			return Chalk{}, fmt.Errorf("style %q: unknown token %q", spec, tok)
+		}
+		if bg {
+			v += 10
+		}
+		c = c.code(v)
+	}
+	return c, nil
+}
+
+func MustStyle(spec string) Chalk {
+	c, err := ParseStyle(spec)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (c Chalk) Spec() string {
+	out := make([]string, 0, len(c.codes))
+	for _, code := range c.codes {
+		out = append(out, specForCode(code))
+	}
+	return strings.Join(out, " ")
+}
+
+func (c Chalk) MarshalText() ([]byte, error) {
+	return []byte(c.Spec()), nil
+}
+
+func (c *Chalk) UnmarshalText(text []byte) error {
+	parsed, err := ParseStyle(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+func namedColorCode(name string) (int, bool) {
+	name = strings.NewReplacer("-", "", "_", "").Replace(name)
+	if name == "gray" || name == "grey" {
+		return 90, true
+	}
+	bright := strings.HasPrefix(name, "bright")
+	name = strings.TrimPrefix(name, "bright")
+	for i, n := range specColors {
+		if n != name {
+			continue
+		}
+		if bright {
+			return 90 + i, true
+		}
+		return 30 + i, true
+	}
+	return 0, false
+}
+
+func specForCode(code string) string {
+	if parts := strings.Split(code, ";"); len(parts) == 5 && parts[1] == "2" && (parts[0] == "38" || parts[0] == "48") {
+		var rgb [3]uint64
+		for i := range rgb {
+			v, err := strconv.ParseUint(parts[i+2], 10, 8)
+			if err != nil {
+				return "sgr:" + code
+			}
+			rgb[i] = v
+		}
+		hex := fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+		if parts[0] == "48" {
+			return "bg:" + hex
+		}
+		return hex
+	}
+	v, err := strconv.Atoi(code)
+	if err != nil {
+		return "sgr:" + code
+	}
+	for name, m := range specModifiers {
+		if m == v {
+			return name
+		}
+	}
+	switch {
+	case v == 90:
+		return "gray"
+	case v >= 30 && v <= 37:
+		return specColors[v-30]
+	case v >= 40 && v <= 47:
+		return "bg:" + specColors[v-40]
+	case v >= 91 && v <= 97:
+		return "bright-" + specColors[v-90]
+	case v >= 100 && v <= 107:
+		return "bg:bright-" + specColors[v-100]
+	}
+	return "sgr:" + code
+}
@@ -1,28 +1,68 @@
 package style
 
+import (
+	"fmt"
+	"strings"
+)
+
+type Variant int
+
+const (
+	VariantDark Variant = iota
+	VariantLight
+)
+
+func (v Variant) String() string {
+	if v == VariantLight {
+		return "light"
+	}
+	return "dark"
+}
+
+func (v Variant) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Variant) UnmarshalText(text []byte) error {
+	switch strings.ToLower(strings.TrimSpace(string(text))) {
+	case "", "dark":
+		*v = VariantDark
+	case "light":
+		*v = VariantLight
+	default:
+		return fmt.Errorf("unknown theme variant %q", text)
+	}
+	return nil
+}
+
 type Theme struct {
-	TimeKey   Chalk
-	TimeValue Chalk
-	MsgKey    Chalk
-	Debug     Chalk
-	Info      Chalk
-	Warn      Chalk
-	Error     Chalk
-	ErrKey    Chalk
-	PlayerKey Chalk
-	WorldKey  Chalk
+	Name      string  `json:"name,omitempty"`
+	Variant   Variant `json:"variant,omitempty"`
+	TimeKey   Chalk   `json:"time_key"`
+	TimeValue Chalk   `json:"time_value"`
+	MsgKey    Chalk   `json:"msg_key"`
+	Debug     Chalk   `json:"debug"`
+	Info      Chalk   `json:"info"`
+	Warn      Chalk   `json:"warn"`
+	Error     Chalk   `json:"error"`
+	ErrKey    Chalk   `json:"err_key"`
+	PlayerKey Chalk   `json:"player_key"`
+	WorldKey  Chalk   `json:"world_key"`
+
+	Tokens map[string]Chalk `json:"tokens,omitempty"`
 }
 
 func DefaultTheme() Theme {
 	return Theme{
+		Name:      "default",
 		TimeKey:   New().Gray(),
 		TimeValue: New().BrightBlue(),
 		MsgKey:    New().BrightWhite(),
 		Debug:     New().Black().BgCyan().Bold(),
 		Info:      New().Black().BgHex("#40E0D0").Bold(),
 		Warn:      New().Black().BgYellow().Bold(),
-		Error:     New().White().BgRed().Bold(),
-		ErrKey:    New().White().BgRed().Bold(),
+		Error:     New().BrightWhite().BgRed().Bold(),
+		ErrKey:    New().BrightWhite().BgRed().Bold(),
 		PlayerKey: New().BrightCyan(),
 		WorldKey:  New().Magenta(),
 	}
@@ -30,14 +70,15 @@ func DefaultTheme() Theme {
 
 func NordTheme() Theme {
 	return Theme{
+		Name:      "nord",
 		TimeKey:   New().Hex("#81A1C1"),
 		TimeValue: New().Hex("#88C0D0"),
 		MsgKey:    New().Hex("#ECEFF4").Bold(),
 		Debug:     New().Black().BgHex("#88C0D0").Bold(),
 		Info:      New().Black().BgHex("#8FBCBB").Bold(),
 		Warn:      New().Black().BgHex("#EBCB8B").Bold(),
-		Error:     New().Hex("#ECEFF4").BgHex("#BF616A").Bold(),
-		ErrKey:    New().Hex("#ECEFF4").BgHex("#D08770").Bold(),
+		Error:     New().Black().BgHex("#BF616A").Bold(),
+		ErrKey:    New().Black().BgHex("#D08770").Bold(),
 		PlayerKey: New().Hex("#B48EAD"),
 		WorldKey:  New().Hex("#5E81AC"),
 	}
@@ -45,14 +86,15 @@ func NordTheme() Theme {
 
 func SunsetTheme() Theme {
 	return Theme{
+		Name:      "sunset",
 		TimeKey:   New().Hex("#F8C8DC"),
 		TimeValue: New().Hex("#F4A261"),
 		MsgKey:    New().Hex("#FFF1E6"),
 		Debug:     New().Black().BgHex("#9BF6FF").Bold(),
 		Info:      New().Black().BgHex("#70E0C0").Bold(),
 		Warn:      New().Black().BgHex("#FFD166").Bold(),
-		Error:     New().Hex("#FFF1E6").BgHex("#FF6B6B").Bold(),
-		ErrKey:    New().Hex("#FFF1E6").BgHex("#FF8FA3").Bold(),
+		Error:     New().Black().BgHex("#FF6B6B").Bold(),
+		ErrKey:    New().Black().BgHex("#FF8FA3").Bold(),
 		PlayerKey: New().Hex("#CDB4DB"),
 		WorldKey:  New().Hex("#A0C4FF"),
 	}
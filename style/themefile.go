@@ -0,0 +1,294 @@
+package style
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]Theme{
+		"default": DefaultTheme(),
+		"nord":    NordTheme(),
+		"sunset":  SunsetTheme(),
+
+		"high-contrast":       HighContrastTheme(),
+		"high-contrast-light": HighContrastLightTheme(),
+		"colorblind":          ColorBlindTheme(),
+		"colorblind-light":    ColorBlindLightTheme(),
+	}
+)
+
+func RegisterTheme(name string, t Theme) {
+	name = normalizeThemeName(name)
+	if name == "" {
+		return
+	}
+	t.Name = name
+	themesMu.Lock()
+	themes[name] = t
+	themesMu.Unlock()
+}
+
+func LookupTheme(name string) (Theme, bool) {
+	themesMu.RLock()
+	t, ok := themes[normalizeThemeName(name)]
+	themesMu.RUnlock()
+	return t, ok
+}
+
+func ThemeNames() []string {
+	themesMu.RLock()
+	out := make([]string, 0, len(themes))
+	for name := range themes {
+		out = append(out, name)
+	}
+	themesMu.RUnlock()
+	sort.Strings(out)
+	return out
+}
+
+func normalizeThemeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+type themeSlot struct {
+	key   string
+	chalk *Chalk
+}
+
+func (t *Theme) slots() []themeSlot {
+	return []themeSlot{
+		{"time_key", &t.TimeKey},
+		{"time_value", &t.TimeValue},
+		{"msg_key", &t.MsgKey},
+		{"debug", &t.Debug},
+		{"info", &t.Info},
+		{"warn", &t.Warn},
+		{"error", &t.Error},
+		{"err_key", &t.ErrKey},
+		{"player_key", &t.PlayerKey},
+		{"world_key", &t.WorldKey},
+	}
+}
+
+func LoadTheme(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	t, err := ParseTheme(data)
+	if err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if t.Name == "" {
+		t.Name = normalizeThemeName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+	return t, nil
+}
+
+func SaveTheme(path string, t Theme) error {
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		out, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(out, '\n')
+	} else {
+		data = MarshalThemeTOML(t)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func ParseTheme(data []byte) (Theme, error) {
+	trimmed := bytes.TrimSpace(data)
+	var (
+		entries map[string]string
+		err     error
+	)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		entries, err = parseThemeJSON(trimmed)
+	} else {
+		entries, err = parseThemeTOML(data)
+	}
+	if err != nil {
+		return Theme{}, err
+	}
+	return themeFromEntries(entries)
+}
+
+func MarshalThemeTOML(t Theme) []byte {
+	var b bytes.Buffer
+	if t.Name != "" {
+		fmt.Fprintf(&b, "name = %s\n", strconv.Quote(t.Name))
+	}
+	if t.Variant != VariantDark {
+		fmt.Fprintf(&b, "variant = %s\n", strconv.Quote(t.Variant.String()))
+	}
+	for _, s := range t.slots() {
+		fmt.Fprintf(&b, "%s = %s\n", s.key, strconv.Quote(s.chalk.Spec()))
+	}
+	if len(t.Tokens) > 0 {
+		names := make([]string, 0, len(t.Tokens))
+		for name := range t.Tokens {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\n[tokens]\n")
+		for _, name := range names {
+			c := t.Tokens[name]
+			fmt.Fprintf(&b, "%s = %s\n", name, strconv.Quote(c.Spec()))
+		}
+	}
+	return b.Bytes()
+}
+
+func themeFromEntries(entries map[string]string) (Theme, error) {
+	baseName := "default"
+	if v, ok := entries["base"]; ok {
+		baseName = v
+	}
+	t, ok := LookupTheme(baseName)
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown base theme %q", baseName)
+	}
+	tokens := make(map[string]Chalk, len(t.Tokens))
+	for name, c := range t.Tokens {
+		tokens[name] = c
+	}
+	t.Name = ""
+	t.Tokens = tokens
+	slots := map[string]*Chalk{}
+	for _, s := range t.slots() {
+		slots[s.key] = s.chalk
+	}
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := entries[k]
+		switch k {
+		case "base":
+		case "name":
+			t.Name = normalizeThemeName(v)
+		case "variant":
+			if err := t.Variant.UnmarshalText([]byte(v)); err != nil {
+				return Theme{}, err
+			}
+		default:
+			if name, ok := strings.CutPrefix(k, "tokens."); ok {
+				c, err := ParseStyle(v)
+				if err != nil {
+					return Theme{}, err
+				}
+				t.Tokens[strings.ToLower(name)] = c
+				continue
+			}
+			slot, ok := slots[k]
+			if !ok {
+				return Theme{}, fmt.Errorf("unknown theme key %q", k)
+			}
+			c, err := ParseStyle(v)
+			if err != nil {
+				return Theme{}, err
+			}
+			*slot = c
+		}
+	}
+	return t, nil
+}
+
+func parseThemeJSON(data []byte) (map[string]string, error) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if tokens, ok := v.(map[string]any); ok && k == "tokens" {
+			for name, tv := range tokens {
+				s, ok := tv.(string)
+				if !ok {
+					return nil, fmt.Errorf("theme token %q: expected string", name)
+				}
+				out["tokens."+name] = s
+			}
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("theme key %q: expected string", k)
+		}
+		out[k] = s
+	}
+	return out, nil
+}
+
+func parseThemeTOML(data []byte) (map[string]string, error) {
+	out := map[string]string{}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	section := ""
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(stripThemeComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		i := strings.IndexByte(line, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key := strings.TrimSpace(line[:i])
+		if uq, err := strconv.Unquote(key); err == nil {
+			key = uq
+		}
+		value := strings.TrimSpace(line[i+1:])
+		if strings.HasPrefix(value, "\"") {
+			uq, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			value = uq
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		out[key] = value
+	}
+	return out, sc.Err()
+}
+
+func stripThemeComment(line string) string {
+	inQuotes := false
+	escaped := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == '#' && !inQuotes:
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,117 @@
+package style
+
+import (
+	"sort"
+	"strings"
+)
+
+var coreTokens = map[string]func(t Theme) Chalk{
+	"time.key":    func(t Theme) Chalk { return t.TimeKey },
+	"time":        func(t Theme) Chalk { return t.TimeValue },
+	"msg":         func(t Theme) Chalk { return t.MsgKey },
+	"level.debug": func(t Theme) Chalk { return t.Debug },
+	"level.info":  func(t Theme) Chalk { return t.Info },
+	"level.warn":  func(t Theme) Chalk { return t.Warn },
+	"level.error": func(t Theme) Chalk { return t.Error },
+	"attr.err":    func(t Theme) Chalk { return t.ErrKey },
+	"attr.error":  func(t Theme) Chalk { return t.ErrKey },
+	"attr.player": func(t Theme) Chalk { return t.PlayerKey },
+	"attr.world":  func(t Theme) Chalk { return t.WorldKey },
+}
+
+var tokenFallbacks = map[string]string{
+	"muted":               "time.key",
+	"value":               "time",
+	"accent":              "time",
+	"field.key":           "muted",
+	"number":              "value",
+	"duration":            "number",
+	"command":             "accent",
+	"command.description": "muted",
+	"string":              "attr.player",
+	"constant":            "attr.world",
+	"link":                "accent",
+	"identifier":          "muted",
+	"value.number":        "number",
+	"value.duration":      "duration",
+	"value.string":        "string",
+	"value.bool":          "constant",
+	"value.nil":           "muted",
+	"value.address":       "link",
+	"value.url":           "link",
+	"value.path":          "link",
+	"value.uuid":          "identifier",
+	"value.pointer":       "identifier",
+	"level.trace":         "level.debug",
+	"level.notice":        "level.info",
+	"level.fatal":         "level.error",
+}
+
+const maxTokenDepth = 16
+
+func (c Chalk) IsZero() bool {
+	return !c.enabled && len(c.codes) == 0
+}
+
+func (t Theme) Style(name string) Chalk {
+	c, _ := t.Lookup(name)
+	return c
+}
+
+func (t Theme) Override(name string) (Chalk, bool) {
+	c, ok := t.Tokens[strings.ToLower(strings.TrimSpace(name))]
+	return c, ok
+}
+
+func (t Theme) Lookup(name string) (Chalk, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for depth := 0; name != "" && depth < maxTokenDepth; depth++ {
+		if c, ok := t.Tokens[name]; ok {
+			return c, true
+		}
+		if get, ok := coreTokens[name]; ok {
+			if c := get(t); !c.IsZero() {
+				return c, true
+			}
+		}
+		if next, ok := tokenFallbacks[name]; ok {
+			name = next
+			continue
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return Chalk{}, false
+}
+
+func (t Theme) WithToken(name string, c Chalk) Theme {
+	tokens := make(map[string]Chalk, len(t.Tokens)+1)
+	for k, v := range t.Tokens {
+		tokens[k] = v
+	}
+	tokens[strings.ToLower(strings.TrimSpace(name))] = c
+	t.Tokens = tokens
+	return t
+}
+
+func (t Theme) TokenNames() []string {
+	seen := map[string]struct{}{}
+	for name := range coreTokens {
+		seen[name] = struct{}{}
+	}
+	for name := range tokenFallbacks {
+		seen[name] = struct{}{}
+	}
+	for name := range t.Tokens {
+		seen[name] = struct{}{}
+	}
+	out := make([]string, 0, len(seen))
+	for name := range seen {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
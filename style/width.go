@@ -0,0 +1,411 @@
+package style
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type segmentKind int
+
+const (
+	segmentText segmentKind = iota
+	segmentSpace
+	segmentEscape
+	segmentNewline
+)
+
+type segment struct {
+	text  string
+	width int
+	kind  segmentKind
+}
+
+func Width(s string) int {
+	maxW, lineW := 0, 0
+	for _, seg := range splitSegments(s) {
+		if seg.kind == segmentNewline {
+			lineW = 0
+			continue
+		}
+		lineW += seg.width
+		if lineW > maxW {
+			maxW = lineW
+		}
+	}
+	return maxW
+}
+
+func Truncate(s string, w int, ellipsis string) string {
+	if w <= 0 {
+		return ""
+	}
+	if Width(s) <= w {
+		return s
+	}
+	ew := Width(ellipsis)
+	if ew > w {
+		return Truncate(ellipsis, w, "")
+	}
+	limit := w - ew
+	var b strings.Builder
+	width := 0
+	styled := false
+	for _, seg := range splitSegments(s) {
+		if seg.kind == segmentEscape {
+			b.WriteString(seg.text)
+			styled = trackStyled(styled, seg.text)
+			continue
+		}
+		if seg.kind == segmentNewline || width+seg.width > limit {
+			break
+		}
+		b.WriteString(seg.text)
+		width += seg.width
+	}
+	b.WriteString(ellipsis)
+	if styled {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
+
+func TruncateMiddle(s string, w int, ellipsis string) string {
+	if w <= 0 {
+		return ""
+	}
+	if Width(s) <= w {
+		return s
+	}
+	ew := Width(ellipsis)
+	if ew > w {
+		return Truncate(ellipsis, w, "")
+	}
+	segs := splitSegments(s)
+	limit := w - ew
+	head := (limit + 1) / 2
+	tailStart := segmentsWidth(segs) - (limit - head)
+	var b strings.Builder
+	pos := 0
+	elided := false
+	for _, seg := range segs {
+		if seg.kind == segmentEscape {
+			b.WriteString(seg.text)
+			continue
+		}
+		switch {
+		case !elided && pos+seg.width <= head:
+			b.WriteString(seg.text)
+		case pos >= tailStart:
+			if !elided {
+				b.WriteString(ellipsis)
+				elided = true
+			}
+			b.WriteString(seg.text)
+		case !elided:
+			b.WriteString(ellipsis)
+			elided = true
+		}
+		pos += seg.width
+	}
+	if !elided {
+		b.WriteString(ellipsis)
+	}
+	return b.String()
+}
+
+func PadRight(s string, w int) string {
+	if pad := w - Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+func PadLeft(s string, w int) string {
+	if pad := w - Width(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
+func Center(s string, w int) string {
+	pad := w - Width(s)
+	if pad <= 0 {
+		return s
+	}
+	left := pad / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", pad-left)
+}
+
+func Wrap(s string, w, indent int) string {
+	if w <= 0 || indent >= w {
+		return s
+	}
+	var (
+		b       strings.Builder
+		active  []string
+		pending []segment
+		word    []segment
+		lineW   int
+		fresh   = true
+	)
+	emit := func(seg segment) {
+		b.WriteString(seg.text)
+		if seg.kind == segmentEscape {
+			active = trackActive(active, seg.text)
+			return
+		}
+		lineW += seg.width
+		fresh = false
+	}
+	breakLine := func() {
+		if len(active) > 0 {
+			b.WriteString(ansiReset)
+		}
+		b.WriteByte('\n')
+		b.WriteString(strings.Repeat(" ", indent))
+		for _, code := range active {
+			b.WriteString(code)
+		}
+		lineW = indent
+		fresh = true
+	}
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		ww, pw := segmentsWidth(word), segmentsWidth(pending)
+		if !fresh && lineW+pw+ww > w {
+			breakLine()
+			for _, seg := range pending {
+				if seg.kind == segmentEscape {
+					emit(seg)
+				}
+			}
+		} else {
+			for _, seg := range pending {
+				emit(seg)
+			}
+		}
+		pending = pending[:0]
+		for _, seg := range word {
+			if seg.kind != segmentEscape && lineW+seg.width > w && !fresh {
+				breakLine()
+			}
+			emit(seg)
+		}
+		word = word[:0]
+	}
+	for _, seg := range splitSegments(s) {
+		switch seg.kind {
+		case segmentSpace:
+			flushWord()
+			pending = append(pending, seg)
+		case segmentNewline:
+			flushWord()
+			for _, p := range pending {
+				emit(p)
+			}
+			pending = pending[:0]
+			breakLine()
+		case segmentEscape:
+			if len(word) == 0 {
+				pending = append(pending, seg)
+				continue
+			}
+			word = append(word, seg)
+		default:
+			word = append(word, seg)
+		}
+	}
+	flushWord()
+	for _, seg := range pending {
+		emit(seg)
+	}
+	return b.String()
+}
+
+const ansiReset = "\x1b[0m"
+
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+func isReset(seq string) bool {
+	return seq == ansiReset || seq == "\x1b[m"
+}
+
+func trackStyled(styled bool, seq string) bool {
+	if !isSGR(seq) {
+		return styled
+	}
+	return !isReset(seq)
+}
+
+func trackActive(active []string, seq string) []string {
+	if !isSGR(seq) {
+		return active
+	}
+	if isReset(seq) {
+		return active[:0]
+	}
+	return append(active, seq)
+}
+
+func segmentsWidth(segs []segment) int {
+	w := 0
+	for _, seg := range segs {
+		w += seg.width
+	}
+	return w
+}
+
+func splitSegments(s string) []segment {
+	out := make([]segment, 0, len(s))
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == 0x1b:
+			n := escapeLen(s[i:])
+			out = append(out, segment{text: s[i : i+n], kind: segmentEscape})
+			i += n
+			continue
+		case c == '\n':
+			out = append(out, segment{text: "\n", kind: segmentNewline})
+			i++
+			continue
+		case c == ' ' || c == '\t':
+			out = append(out, segment{text: s[i : i+1], width: 1, kind: segmentSpace})
+			i++
+			continue
+		}
+		n, w := clusterLen(s[i:])
+		out = append(out, segment{text: s[i : i+n], width: w, kind: segmentText})
+		i += n
+	}
+	return out
+}
+
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']', 'P', '_', '^':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	return 2
+}
+
+func clusterLen(s string) (n, width int) {
+	r, size := utf8.DecodeRuneInString(s)
+	n = size
+	width = runeWidth(r)
+	regional := isRegionalIndicator(r)
+	for n < len(s) {
+		next, nsize := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case next == 0x200d:
+			n += nsize
+			if n < len(s) && s[n] != 0x1b {
+				_, fsize := utf8.DecodeRuneInString(s[n:])
+				n += fsize
+			}
+			if width < 2 {
+				width = 2
+			}
+		case next == 0xfe0f:
+			n += nsize
+			width = 2
+		case regional && isRegionalIndicator(next):
+			n += nsize
+			regional = false
+			width = 2
+		case isExtender(next):
+			n += nsize
+		default:
+			return n, width
+		}
+	}
+	return n, width
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+func isExtender(r rune) bool {
+	switch {
+	case r >= 0xfe00 && r <= 0xfe0f, r >= 0xe0100 && r <= 0xe01ef:
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff:
+		return true
+	case r >= 0xe0020 && r <= 0xe007f:
+		return true
+	case r == 0x200c:
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == utf8.RuneError:
+		return 1
+	case r < 0x20, r >= 0x7f && r < 0xa0:
+		return 0
+	case r < 0x1100:
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			return 0
+		}
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	if i < len(wideRanges) && wideRanges[i][0] <= r {
+		return 2
+	}
+	return 1
+}
+
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec},
+	{0x23f0, 0x23f0}, {0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce},
+	{0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27b0, 0x27b0}, {0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4},
+	{0x17000, 0x18aff}, {0x1b000, 0x1b2ff}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf},
+	{0x1f18e, 0x1f18e}, {0x1f191, 0x1f19a}, {0x1f200, 0x1f202}, {0x1f210, 0x1f23b},
+	{0x1f240, 0x1f248}, {0x1f250, 0x1f251}, {0x1f260, 0x1f265}, {0x1f300, 0x1f320},
+	{0x1f32d, 0x1f335}, {0x1f337, 0x1f37c}, {0x1f37e, 0x1f393}, {0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3}, {0x1f3e0, 0x1f3f0}, {0x1f3f4, 0x1f3f4}, {0x1f3f8, 0x1f43e},
+	{0x1f440, 0x1f440}, {0x1f442, 0x1f4fc}, {0x1f4ff, 0x1f53d}, {0x1f54b, 0x1f54e},
+	{0x1f550, 0x1f567}, {0x1f57a, 0x1f57a}, {0x1f595, 0x1f596}, {0x1f5a4, 0x1f5a4},
+	{0x1f5fb, 0x1f64f}, {0x1f680, 0x1f6c5}, {0x1f6cc, 0x1f6cc}, {0x1f6d0, 0x1f6d2},
+	{0x1f6d5, 0x1f6d7}, {0x1f6dc, 0x1f6df}, {0x1f6eb, 0x1f6ec}, {0x1f6f4, 0x1f6fc},
+	{0x1f7e0, 0x1f7eb}, {0x1f7f0, 0x1f7f0}, {0x1f90c, 0x1f93a}, {0x1f93c, 0x1f945},
+	{0x1f947, 0x1f9ff}, {0x1fa70, 0x1faff}, {0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
@@ -0,0 +1,140 @@
+package style
+
+import "testing"
+
+const (
+	family = "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+	flagJP = "\U0001F1EF\U0001F1F5"
+	flagFR = "\U0001F1EB\U0001F1F7"
+	eAcute = "e\u0301"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"日本語", 6},
+		{eAcute, 1},
+		{eAcute + eAcute + "x", 3},
+		{family, 2},
+		{flagJP, 2},
+		{flagJP + flagFR, 4},
+		{"❤️", 2},
+		{"\U0001F44D\U0001F3FD", 2},
+		{"\x1b[31mred\x1b[0m", 3},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"ab\ncdef\ng", 4},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.in); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in       string
+		w        int
+		ellipsis string
+		want     string
+	}{
+		{"hello", 5, "…", "hello"},
+		{"hello world", 8, "…", "hello w…"},
+		{"hello", 0, "…", ""},
+		{"hello", -1, "…", ""},
+		{"hello", -1, "", ""},
+		{"hello", 2, "...", ".."},
+		{"日本語テキスト", 7, "…", "日本語…"},
+		{"日本語", 5, "", "日本"},
+		{eAcute + eAcute + eAcute, 2, "…", eAcute + "…"},
+		{family + family, 3, "…", family + "…"},
+		{flagJP + flagFR, 3, "…", flagJP + "…"},
+		{"\x1b[31mhello world\x1b[0m", 6, "…", "\x1b[31mhello…\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.w, tt.ellipsis); got != tt.want {
+			t.Errorf("Truncate(%q, %d, %q) = %q, want %q", tt.in, tt.w, tt.ellipsis, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	tests := []struct {
+		in       string
+		w        int
+		ellipsis string
+		want     string
+	}{
+		{"abc", 3, "…", "abc"},
+		{"abcdefghij", 5, "…", "ab…ij"},
+		{"abcdefghij", 6, "…", "abc…ij"},
+		{"abcdefghij", 0, "…", ""},
+		{"abcdefghij", -3, "…", ""},
+		{"abc", 2, "...", ".."},
+		{"日本語テキスト", 7, "…", "日…ト"},
+		{eAcute + "bcd" + eAcute, 3, "…", eAcute + "…" + eAcute},
+		{flagJP + "xx" + flagFR, 5, "…", flagJP + "…" + flagFR},
+		{"\x1b[1mabcdefghij\x1b[0m", 5, "…", "\x1b[1mab…ij\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := TruncateMiddle(tt.in, tt.w, tt.ellipsis); got != tt.want {
+			t.Errorf("TruncateMiddle(%q, %d, %q) = %q, want %q", tt.in, tt.w, tt.ellipsis, got, tt.want)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	styled := "\x1b[31mx\x1b[0m"
+	tests := []struct {
+		name string
+		fn   func(string, int) string
+		in   string
+		w    int
+		want string
+	}{
+		{"PadRight", PadRight, "日本", 6, "日本  "},
+		{"PadRight", PadRight, styled, 3, styled + "  "},
+		{"PadRight", PadRight, "toolong", 3, "toolong"},
+		{"PadLeft", PadLeft, eAcute, 3, "  " + eAcute},
+		{"PadLeft", PadLeft, flagJP, 4, "  " + flagJP},
+		{"PadLeft", PadLeft, family, -1, family},
+		{"Center", Center, "ab", 6, "  ab  "},
+		{"Center", Center, "ab", 5, " ab  "},
+		{"Center", Center, "語", 4, " 語 "},
+		{"Center", Center, styled, 3, " " + styled + " "},
+	}
+	for _, tt := range tests {
+		if got := tt.fn(tt.in, tt.w); got != tt.want {
+			t.Errorf("%s(%q, %d) = %q, want %q", tt.name, tt.in, tt.w, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		in        string
+		w, indent int
+		want      string
+	}{
+		{"the quick brown fox", 10, 0, "the quick\nbrown fox"},
+		{"aaa bbb ccc", 7, 2, "aaa bbb\n  ccc"},
+		{"abcdefgh", 3, 0, "abc\ndef\ngh"},
+		{"a\nb", 5, 2, "a\n  b"},
+		{"日本語 日本語", 6, 0, "日本語\n日本語"},
+		{"日本語テキスト", 5, 0, "日本\n語テ\nキス\nト"},
+		{eAcute + eAcute + " " + eAcute, 2, 0, eAcute + eAcute + "\n" + eAcute},
+		{family + " " + flagJP, 3, 0, family + "\n" + flagJP},
+		{"\x1b[31mred fox\x1b[0m", 3, 0, "\x1b[31mred\x1b[0m\n\x1b[31mfox\x1b[0m"},
+		{"unchanged", 0, 0, "unchanged"},
+		{"unchanged", 4, 4, "unchanged"},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.in, tt.w, tt.indent); got != tt.want {
+			t.Errorf("Wrap(%q, %d, %d) = %q, want %q", tt.in, tt.w, tt.indent, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package term
 import (
 	"os"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
@@ -14,11 +15,29 @@ var (
 	kernel32           = syscall.NewLazyDLL("kernel32.dll")
 	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
 	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
 )
 
+type coord struct {
+	x, y int16
+}
+
+type smallRect struct {
+	left, top, right, bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+	size              coord
+	cursorPosition    coord
+	attributes        uint16
+	window            smallRect
+	maximumWindowSize coord
+}
+
 func EnableConsoleANSI() {
-	enableHandleANSI(os.Stdout)
-	enableHandleANSI(os.Stderr)
+	enableHandleANSI(Stdout())
+	enableHandleANSI(Stderr())
 }
 
 func enableHandleANSI(f *os.File) {
@@ -33,3 +52,28 @@ func enableHandleANSI(f *os.File) {
 	}
 	_, _, _ = procSetConsoleMode.Call(fd, uintptr(mode|enableVirtualTerminalProcessing))
 }
+
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	var mode uint32
+	r1, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	return r1 != 0
+}
+
+func queryTerminal(request string, timeout time.Duration) ([]byte, bool) {
+	return nil, false
+}
+
+func Size(f *os.File) (width, height int, ok bool) {
+	if f == nil {
+		return 0, 0, false
+	}
+	var info consoleScreenBufferInfo
+	r1, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if r1 == 0 {
+		return 0, 0, false
+	}
+	return int(info.window.right-info.window.left) + 1, int(info.window.bottom-info.window.top) + 1, true
+}
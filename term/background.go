@@ -0,0 +1,99 @@
+package term
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Background int
+
+const (
+	BackgroundUnknown Background = iota
+	BackgroundDark
+	BackgroundLight
+)
+
+func (b Background) String() string {
+	switch b {
+	case BackgroundDark:
+		return "dark"
+	case BackgroundLight:
+		return "light"
+	default:
+		return "unknown"
+	}
+}
+
+const DefaultQueryTimeout = 100 * time.Millisecond
+
+func DetectBackground(timeout time.Duration) Background {
+	if r, g, b, ok := QueryBackgroundColor(timeout); ok {
+		return backgroundForColor(r, g, b)
+	}
+	return backgroundFromEnv(os.Getenv("COLORFGBG"))
+}
+
+func QueryBackgroundColor(timeout time.Duration) (r, g, b uint8, ok bool) {
+	if timeout <= 0 {
+		timeout = DefaultQueryTimeout
+	}
+	resp, ok := queryTerminal("\x1b]11;?\x1b\\", timeout)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	return parseOSCColor(string(resp))
+}
+
+func parseOSCColor(resp string) (r, g, b uint8, ok bool) {
+	i := strings.Index(resp, "rgb:")
+	if i < 0 {
+		return 0, 0, 0, false
+	}
+	body := resp[i+4:]
+	if j := strings.IndexAny(body, "\a\x1b"); j >= 0 {
+		body = body[:j]
+	}
+	parts := strings.Split(body, "/")
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+	var out [3]uint8
+	for k, p := range parts {
+		if len(p) == 0 || len(p) > 4 {
+			return 0, 0, 0, false
+		}
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		maxV := uint64(1)<<(4*len(p)) - 1
+		out[k] = uint8(v * 255 / maxV)
+	}
+	return out[0], out[1], out[2], true
+}
+
+func backgroundForColor(r, g, b uint8) Background {
+	lum := 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+	if lum > 127.5 {
+		return BackgroundLight
+	}
+	return BackgroundDark
+}
+
+func backgroundFromEnv(value string) Background {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return BackgroundUnknown
+	}
+	parts := strings.Split(value, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return BackgroundUnknown
+	}
+	if bg == 7 || (bg >= 9 && bg <= 15) {
+		return BackgroundLight
+	}
+	return BackgroundDark
+}
@@ -0,0 +1,74 @@
+package term
+
+import "sync"
+
+const da1Request = "\x1b[c"
+
+var (
+	pendingMu sync.Mutex
+	pending   []byte
+)
+
+func PendingInput() []byte {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	out := pending
+	pending = nil
+	return out
+}
+
+func keepPending(b []byte) {
+	pendingMu.Lock()
+	pending = append(pending, b...)
+	pendingMu.Unlock()
+}
+
+func scanQueryReply(input []byte) (reply, rest []byte, done bool) {
+	for i := 0; i < len(input); i++ {
+		if input[i] != 0x1b {
+			rest = append(rest, input[i])
+			continue
+		}
+		if i+1 >= len(input) {
+			return reply, rest, false
+		}
+		switch {
+		case input[i+1] == ']':
+			end, ok := oscEnd(input, i+2)
+			if !ok {
+				return reply, rest, false
+			}
+			reply = append(reply, input[i:end]...)
+			i = end - 1
+		case input[i+1] == '[' && i+2 < len(input) && input[i+2] == '?':
+			j := i + 3
+			for j < len(input) && (input[j] >= '0' && input[j] <= '9' || input[j] == ';') {
+				j++
+			}
+			if j >= len(input) {
+				return reply, rest, false
+			}
+			if input[j] == 'c' {
+				return reply, append(rest, input[j+1:]...), true
+			}
+			rest = append(rest, input[i])
+		case input[i+1] == '[' && i+2 >= len(input):
+			return reply, rest, false
+		default:
+			rest = append(rest, input[i])
+		}
+	}
+	return reply, rest, false
+}
+
+func oscEnd(input []byte, from int) (int, bool) {
+	for j := from; j < len(input); j++ {
+		switch {
+		case input[j] == '\a':
+			return j + 1, true
+		case input[j] == 0x1b && j+1 < len(input) && input[j+1] == '\\':
+			return j + 2, true
+		}
+	}
+	return 0, false
+}
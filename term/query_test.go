@@ -0,0 +1,30 @@
+package term
+
+import "testing"
+
+func TestScanQueryReply(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		reply string
+		rest  string
+		done  bool
+	}{
+		{"osc then da1", "\x1b]11;rgb:0000/0000/0000\x1b\\\x1b[?62;22c", "\x1b]11;rgb:0000/0000/0000\x1b\\", "", true},
+		{"bel terminator", "\x1b]11;rgb:ffff/ffff/ffff\a\x1b[?1;2c", "\x1b]11;rgb:ffff/ffff/ffff\a", "", true},
+		{"da1 only", "\x1b[?6c", "", "", true},
+		{"typeahead kept", "ls\x1b]11;rgb:1/2/3\a -la\x1b[?1c\r", "\x1b]11;rgb:1/2/3\a", "ls -la\r", true},
+		{"arrow key kept", "\x1b[A\x1b[?1c", "", "\x1b[A", true},
+		{"partial osc", "\x1b]11;rgb:00", "", "", false},
+		{"partial da1", "\x1b]11;rgb:0/0/0\a\x1b[?62", "\x1b]11;rgb:0/0/0\a", "", false},
+		{"no answer", "abc", "", "abc", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply, rest, done := scanQueryReply([]byte(tt.input))
+			if string(reply) != tt.reply || string(rest) != tt.rest || done != tt.done {
+				t.Fatalf("scanQueryReply(%q) = %q, %q, %v; want %q, %q, %v", tt.input, reply, rest, done, tt.reply, tt.rest, tt.done)
+			}
+		})
+	}
+}
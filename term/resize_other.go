@@ -0,0 +1,5 @@
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package term
+
+func startResizeWatch(refresh func()) {}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package term
+
+import "time"
+
+const resizePollInterval = 500 * time.Millisecond
+
+func startResizeWatch(refresh func()) {
+	go func() {
+		for range time.Tick(resizePollInterval) {
+			refresh()
+		}
+	}()
+}
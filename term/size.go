@@ -0,0 +1,66 @@
+package term
+
+import (
+	"sync"
+)
+
+var (
+	sizeMu     sync.Mutex
+	sizeW      int
+	sizeH      int
+	sizeOK     bool
+	watching   bool
+	nextListen int
+	listeners  = map[int]func(width, height int){}
+)
+
+func ConsoleSize() (width, height int, ok bool) {
+	sizeMu.Lock()
+	defer sizeMu.Unlock()
+	if !watching {
+		watching = true
+		sizeW, sizeH, sizeOK = Size(Stdout())
+		startResizeWatch(refreshSize)
+	}
+	return sizeW, sizeH, sizeOK
+}
+
+func ConsoleWidth() int {
+	w, _, ok := ConsoleSize()
+	if !ok {
+		return 0
+	}
+	return w
+}
+
+func OnResize(fn func(width, height int)) (cancel func()) {
+	ConsoleSize()
+	sizeMu.Lock()
+	id := nextListen
+	nextListen++
+	listeners[id] = fn
+	sizeMu.Unlock()
+	return func() {
+		sizeMu.Lock()
+		delete(listeners, id)
+		sizeMu.Unlock()
+	}
+}
+
+func refreshSize() {
+	w, h, ok := Size(Stdout())
+	sizeMu.Lock()
+	changed := w != sizeW || h != sizeH || ok != sizeOK
+	sizeW, sizeH, sizeOK = w, h, ok
+	fns := make([]func(int, int), 0, len(listeners))
+	for _, fn := range listeners {
+		fns = append(fns, fn)
+	}
+	sizeMu.Unlock()
+	if !changed || !ok {
+		return
+	}
+	for _, fn := range fns {
+		fn(w, h)
+	}
+}
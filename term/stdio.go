@@ -0,0 +1,66 @@
+package term
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	stdout atomic.Pointer[os.File]
+	stderr atomic.Pointer[os.File]
+
+	redirectMu sync.Mutex
+	redirected bool
+)
+
+func init() {
+	stdout.Store(os.Stdout)
+	stderr.Store(os.Stderr)
+}
+
+func Stdout() *os.File { return stdout.Load() }
+func Stderr() *os.File { return stderr.Load() }
+
+type consoleWriter struct {
+	f *atomic.Pointer[os.File]
+}
+
+func (w consoleWriter) Write(p []byte) (int, error) {
+	return w.f.Load().Write(p)
+}
+
+func ConsoleOut() io.Writer { return consoleWriter{f: &stdout} }
+func ConsoleErr() io.Writer { return consoleWriter{f: &stderr} }
+
+func RedirectStdio(out, err *os.File) error {
+	redirectMu.Lock()
+	defer redirectMu.Unlock()
+	if redirected {
+		return errors.New("stdio is already redirected")
+	}
+	origOut, origErr, e := redirectStdio(out, err)
+	if e != nil {
+		return e
+	}
+	stdout.Store(origOut)
+	stderr.Store(origErr)
+	redirected = true
+	return nil
+}
+
+func RestoreStdio() error {
+	redirectMu.Lock()
+	defer redirectMu.Unlock()
+	if !redirected {
+		return nil
+	}
+	origOut, origErr := stdout.Load(), stderr.Load()
+	err := restoreStdio(origOut, origErr)
+	stdout.Store(os.Stdout)
+	stderr.Store(os.Stderr)
+	redirected = false
+	return errors.Join(err, releaseStdio(origOut, origErr))
+}
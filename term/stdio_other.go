@@ -0,0 +1,18 @@
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package term
+
+import "os"
+
+func redirectStdio(out, err *os.File) (origOut, origErr *os.File, e error) {
+	origOut, origErr = os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = out, err
+	return origOut, origErr, nil
+}
+
+func restoreStdio(origOut, origErr *os.File) error {
+	os.Stdout, os.Stderr = origOut, origErr
+	return nil
+}
+
+func releaseStdio(origOut, origErr *os.File) error { return nil }
@@ -0,0 +1,50 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package term
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func redirectStdio(out, err *os.File) (origOut, origErr *os.File, e error) {
+	if origOut, e = dupFile(syscall.Stdout, "/dev/stdout"); e != nil {
+		return nil, nil, e
+	}
+	if origErr, e = dupFile(syscall.Stderr, "/dev/stderr"); e != nil {
+		_ = origOut.Close()
+		return nil, nil, e
+	}
+	if e = dup2(int(out.Fd()), syscall.Stdout); e == nil {
+		if e = dup2(int(err.Fd()), syscall.Stderr); e != nil {
+			_ = dup2(int(origOut.Fd()), syscall.Stdout)
+		}
+	}
+	if e != nil {
+		_ = origOut.Close()
+		_ = origErr.Close()
+		return nil, nil, e
+	}
+	return origOut, origErr, nil
+}
+
+func restoreStdio(origOut, origErr *os.File) error {
+	return errors.Join(
+		dup2(int(origOut.Fd()), syscall.Stdout),
+		dup2(int(origErr.Fd()), syscall.Stderr),
+	)
+}
+
+func releaseStdio(origOut, origErr *os.File) error {
+	return errors.Join(origOut.Close(), origErr.Close())
+}
+
+func dupFile(fd int, name string) (*os.File, error) {
+	nfd, err := syscall.Dup(fd)
+	if err != nil {
+		return nil, err
+	}
+	syscall.CloseOnExec(nfd)
+	return os.NewFile(uintptr(nfd), name), nil
+}
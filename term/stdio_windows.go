@@ -0,0 +1,41 @@
+//go:build windows
+
+package term
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+var procSetStdHandle = kernel32.NewProc("SetStdHandle")
+
+func setStdHandle(which int, f *os.File) error {
+	if r1, _, err := procSetStdHandle.Call(uintptr(which), f.Fd()); r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+func redirectStdio(out, err *os.File) (origOut, origErr *os.File, e error) {
+	origOut, origErr = os.Stdout, os.Stderr
+	if e = setStdHandle(syscall.STD_OUTPUT_HANDLE, out); e != nil {
+		return nil, nil, e
+	}
+	if e = setStdHandle(syscall.STD_ERROR_HANDLE, err); e != nil {
+		_ = setStdHandle(syscall.STD_OUTPUT_HANDLE, origOut)
+		return nil, nil, e
+	}
+	os.Stdout, os.Stderr = out, err
+	return origOut, origErr, nil
+}
+
+func restoreStdio(origOut, origErr *os.File) error {
+	os.Stdout, os.Stderr = origOut, origErr
+	return errors.Join(
+		setStdHandle(syscall.STD_OUTPUT_HANDLE, origOut),
+		setStdHandle(syscall.STD_ERROR_HANDLE, origErr),
+	)
+}
+
+func releaseStdio(origOut, origErr *os.File) error { return nil }
@@ -0,0 +1,16 @@
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package term
+
+import (
+	"os"
+	"time"
+)
+
+func IsTerminal(f *os.File) bool { return false }
+
+func queryTerminal(request string, timeout time.Duration) ([]byte, bool) {
+	return nil, false
+}
+
+func Size(f *os.File) (width, height int, ok bool) { return 0, 0, false }
@@ -0,0 +1,92 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package term
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	t := new(syscall.Termios)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlGetTermios, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return nil, errno
+	}
+	return t, nil
+}
+
+func setTermios(fd uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlSetTermios, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	_, err := getTermios(f.Fd())
+	return err == nil
+}
+
+func queryTerminal(request string, timeout time.Duration) ([]byte, bool) {
+	if !IsTerminal(os.Stdin) || !IsTerminal(Stdout()) {
+		return nil, false
+	}
+	in := os.Stdin.Fd()
+	old, err := getTermios(in)
+	if err != nil {
+		return nil, false
+	}
+	raw := *old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 1
+	if err := setTermios(in, &raw); err != nil {
+		return nil, false
+	}
+	if _, err := Stdout().WriteString(request + da1Request); err != nil {
+		_ = setTermios(in, old)
+		return nil, false
+	}
+	deadline := time.Now().Add(timeout)
+	input := make([]byte, 0, 64)
+	buf := make([]byte, 64)
+	for time.Now().Before(deadline) {
+		n, err := syscall.Read(int(in), buf)
+		if err != nil && err != syscall.EINTR && err != syscall.EAGAIN {
+			break
+		}
+		if n > 0 {
+			input = append(input, buf[:n]...)
+			if _, _, done := scanQueryReply(input); done {
+				break
+			}
+		}
+	}
+	reply, rest, _ := scanQueryReply(input)
+	_ = setTermios(in, old)
+	keepPending(rest)
+	return reply, len(reply) > 0
+}
+
+type winsize struct {
+	row, col, xpixel, ypixel uint16
+}
+
+func Size(f *os.File) (width, height int, ok bool) {
+	if f == nil {
+		return 0, 0, false
+	}
+	var ws winsize
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws))); errno != 0 {
+		return 0, 0, false
+	}
+	if ws.col == 0 {
+		return 0, 0, false
+	}
+	return int(ws.col), int(ws.row), true
+}